@@ -1,9 +1,11 @@
 package client
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"net/http"
+	"sync"
 	"time"
 
 	"github.com/dzt-corp/go-etsy/oauth"
@@ -13,18 +15,68 @@ const (
 	defaultBaseURL = "https://api.etsy.com/v3/application/"
 	userAgent      = "go-etsy"
 	expiryDelta    = 1 * time.Minute
+	// refreshTimeout bounds a refresh, which runs detached from the caller's context; see runLocked
+	refreshTimeout = 30 * time.Second
 )
 
+// ErrReauthorizationRequired is returned when the refresh token was revoked or expired.
+// The seller has to go through the OAuth flow again to get a new refresh token.
+var ErrReauthorizationRequired = errors.New("reauthorization required")
+
+// ErrOAuthRequired is returned when a public (API-key-only) client is used for an operation that needs OAuth
+var ErrOAuthRequired = errors.New("operation requires an OAuth access token")
+
+// Token is the OAuth state of a seller
+type Token struct {
+	AccessToken  string
+	RefreshToken string
+	Expiry       time.Time
+	Scopes       oauth.Scopes
+}
+
 type EtsyClient struct {
+	public bool
+	cfg    *Config
+
+	mu                sync.Mutex // guards the token fields
 	accessToken       string
 	accessTokenExpiry time.Time
-	cfg               *Config
+	refreshToken      string
+	scopes            oauth.Scopes
+
+	refreshSem chan struct{} // holds one token while a refresh is in flight; see lockRefresh
+	// loadToken, set by Manager, returns the tenant's stored token; see adoptStoredToken
+	loadToken func(ctx context.Context) (Token, error)
+
+	refresherMu     sync.Mutex // guards the background refresher; see StartRefresher
+	refresherCancel context.CancelFunc
+	refresherDone   chan struct{}
+
+	shopMu sync.Mutex
+	shopID int64
 }
 
 type Config struct {
 	APIKey       string
 	RefreshToken string
 	OAuth        *oauth.OAuthClient
+
+	// BaseURL overrides the Etsy v3 application endpoint used for user and shop lookups.
+	BaseURL string
+	// HTTPClient is used for user and shop lookups. Defaults to http.DefaultClient.
+	HTTPClient *http.Client
+
+	// AccessToken and AccessTokenExpiry seed the client with a still valid access token,
+	// e.g. one loaded from a TokenStore, so the first request does not need a refresh.
+	AccessToken       string
+	AccessTokenExpiry time.Time
+	// Scopes seeds the scopes granted to AccessToken, so required scopes are checked before the first refresh.
+	Scopes oauth.Scopes
+	// OnTokenRefresh is called with the new token after every refresh or code exchange,
+	// so it can be persisted. Etsy rotates refresh tokens on refresh.
+	OnTokenRefresh func(ctx context.Context, token Token) error
+	// RateLimiter, if set, is waited on before every request. Share one between all clients of an API key.
+	RateLimiter *RateLimiter
 }
 
 func (o Config) IsValid() (bool, error) {
@@ -47,41 +99,269 @@ func NewEtsyClient(cfg *Config) (*EtsyClient, error) {
 
 	client := &EtsyClient{}
 	client.cfg = cfg
+	client.refreshToken = cfg.RefreshToken
+	client.accessToken = cfg.AccessToken
+	client.accessTokenExpiry = cfg.AccessTokenExpiry
+	client.scopes = cfg.Scopes
+	client.refreshSem = make(chan struct{}, 1)
 	return client, nil
 }
 
+// NewPublicEtsyClient returns a client that only sends the API key.
+// It can call public operations (active listings, shop lookups, ...); private ones fail with ErrOAuthRequired.
+func NewPublicEtsyClient(apiKey string) (*EtsyClient, error) {
+	if apiKey == "" {
+		return nil, errors.New("API key is required")
+	}
+	return &EtsyClient{public: true, cfg: &Config{APIKey: apiKey}, refreshSem: make(chan struct{}, 1)}, nil
+}
+
+// IsPublic reports whether the client was created with NewPublicEtsyClient
+func (etsy *EtsyClient) IsPublic() bool {
+	return etsy.public
+}
+
+// RequestBefore authorizes req like AuthorizeRequest, honouring ctx while a token refresh is needed.
+// Its signature matches listing.RequestBeforeFn, receipt.RequestBeforeFn and shop.RequestBeforeFn,
+// so it can be passed directly to their WithRequestBefore options.
+func (etsy *EtsyClient) RequestBefore(ctx context.Context, req *http.Request) error {
+	return etsy.AuthorizeRequest(req.WithContext(ctx))
+}
+
+// AuthorizeRequest adds the API key and a valid access token to r.
+// A needed token refresh is bound to r's context, so it stops at the request's deadline or cancellation.
 func (etsy *EtsyClient) AuthorizeRequest(r *http.Request) error {
-	if etsy.accessToken == "" ||
-		etsy.accessTokenExpiry.IsZero() ||
-		etsy.accessTokenExpiry.Round(0).Add(-expiryDelta).Before(time.Now().UTC()) {
-		if err := etsy.RefreshToken(); err != nil {
-			return fmt.Errorf("cannot refresh token. Error: %s", err.Error())
+	if etsy.cfg.RateLimiter != nil {
+		if err := etsy.cfg.RateLimiter.Wait(r.Context()); err != nil {
+			return err
+		}
+	}
+
+	if etsy.public {
+		if op, ok := oauth.OperationFromContext(r.Context()); ok && !op.Public {
+			return fmt.Errorf("%w: %s", ErrOAuthRequired, op.Name)
+		}
+		r.Header.Add("x-api-key", etsy.cfg.APIKey)
+		return nil
+	}
+
+	accessToken, scopes, err := etsy.validToken(r.Context())
+	if err != nil {
+		return fmt.Errorf("cannot refresh token. Error: %w", err)
+	}
+	// Fail before sending when the operation needs scopes the token was not granted.
+	// Etsy does not always report granted scopes; in that case the API decides.
+	if op, ok := oauth.OperationFromContext(r.Context()); ok && scopes != nil {
+		if err := oauth.CheckScopes(op, scopes); err != nil {
+			return err
 		}
 	}
 	r.Header.Add("x-api-key", etsy.cfg.APIKey)
-	r.Header.Add("Authorization", "Bearer "+etsy.accessToken)
+	r.Header.Add("Authorization", "Bearer "+accessToken)
 
 	return nil
 }
 
+// validToken returns the current access token, refreshing it first if it is missing or about to expire.
+// Concurrent callers share a single refresh.
+func (etsy *EtsyClient) validToken(ctx context.Context) (string, oauth.Scopes, error) {
+	if token, scopes, ok := etsy.currentToken(); ok {
+		return token, scopes, nil
+	}
+
+	err := etsy.runLocked(ctx, func(ctx context.Context) error {
+		// Another goroutine may have refreshed while we were waiting.
+		if _, _, ok := etsy.currentToken(); ok {
+			return nil
+		}
+		return etsy.refresh(ctx)
+	})
+	if err != nil {
+		return "", nil, err
+	}
+	token, scopes, _ := etsy.currentToken()
+	return token, scopes, nil
+}
+
+// currentToken returns the access token and whether it is still valid for at least expiryDelta
+func (etsy *EtsyClient) currentToken() (string, oauth.Scopes, bool) {
+	etsy.mu.Lock()
+	defer etsy.mu.Unlock()
+
+	valid := etsy.accessToken != "" &&
+		!etsy.accessTokenExpiry.IsZero() &&
+		!etsy.accessTokenExpiry.Round(0).Add(-expiryDelta).Before(time.Now().UTC())
+	return etsy.accessToken, etsy.scopes, valid
+}
+
 func (etsy *EtsyClient) RefreshToken() error {
-	resp, err := etsy.cfg.OAuth.RefreshToken(etsy.cfg.RefreshToken)
+	return etsy.RefreshTokenContext(context.Background())
+}
+
+// RefreshTokenContext forces a token refresh. It stops waiting when ctx is done,
+// but a refresh already sent to Etsy still completes and is stored.
+func (etsy *EtsyClient) RefreshTokenContext(ctx context.Context) error {
+	if etsy.public {
+		return ErrOAuthRequired
+	}
+	return etsy.runLocked(ctx, etsy.refresh)
+}
+
+// refreshIfCurrent refreshes the token unless it already changed from usedToken,
+// so that many requests rejected with the same token trigger a single refresh.
+func (etsy *EtsyClient) refreshIfCurrent(ctx context.Context, usedToken string) error {
+	return etsy.runLocked(ctx, func(ctx context.Context) error {
+		if token, _, _ := etsy.currentToken(); token != usedToken {
+			return nil
+		}
+		return etsy.refresh(ctx)
+	})
+}
+
+// runLocked runs fn under the refresh lock with a context detached from ctx's cancellation
+// (bounded by refreshTimeout). Etsy rotates the refresh token on every refresh, so abandoning one
+// midway, or before OnTokenRefresh has stored the result, would lose the new refresh token.
+// The caller stops waiting when ctx is done; fn keeps the lock until it returns.
+func (etsy *EtsyClient) runLocked(ctx context.Context, fn func(ctx context.Context) error) error {
+	if err := etsy.lockRefresh(ctx); err != nil {
+		return err
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		defer etsy.unlockRefresh()
+		detached, cancel := context.WithTimeout(context.WithoutCancel(ctx), refreshTimeout)
+		defer cancel()
+		done <- fn(detached)
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// lockRefresh waits for any in-flight refresh to finish, unless ctx is done first.
+// A channel is used instead of a mutex so waiters are not blocked past their deadline.
+func (etsy *EtsyClient) lockRefresh(ctx context.Context) error {
+	select {
+	case etsy.refreshSem <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (etsy *EtsyClient) unlockRefresh() {
+	<-etsy.refreshSem
+}
+
+// refresh exchanges the refresh token for a new access token. Callers must hold the refresh lock
+// and pass a context that is not cancelled with the request; see runLocked.
+func (etsy *EtsyClient) refresh(ctx context.Context) error {
+	if etsy.adoptStoredToken(ctx) {
+		return nil
+	}
+
+	etsy.mu.Lock()
+	refreshToken := etsy.refreshToken
+	etsy.mu.Unlock()
+
+	resp, err := etsy.cfg.OAuth.RefreshTokenContext(ctx, refreshToken)
 	if err != nil {
+		if oauth.IsInvalidGrant(err) {
+			return fmt.Errorf("%w: %w", ErrReauthorizationRequired, err)
+		}
 		return err
 	}
+	return etsy.setToken(ctx, resp)
+}
+
+// adoptStoredToken takes over the stored token when another client of the same tenant already rotated
+// the refresh token, so this client does not refresh with one Etsy has invalidated.
+// Only a token expiring later than ours is newer: a stored token that differs but expires earlier is
+// one we already rotated away from, left behind by a failed OnTokenRefresh.
+// It reports whether the adopted access token is still valid. Callers must hold the refresh lock.
+func (etsy *EtsyClient) adoptStoredToken(ctx context.Context) bool {
+	if etsy.loadToken == nil {
+		return false
+	}
+	stored, err := etsy.loadToken(ctx)
+	if err != nil {
+		return false // fall back to our own refresh token
+	}
 
+	etsy.mu.Lock()
+	defer etsy.mu.Unlock()
+	if stored.RefreshToken == "" || stored.RefreshToken == etsy.refreshToken ||
+		!stored.Expiry.After(etsy.accessTokenExpiry) {
+		return false
+	}
+	etsy.refreshToken = stored.RefreshToken
+	etsy.accessToken, etsy.accessTokenExpiry = stored.AccessToken, stored.Expiry
+	if stored.Scopes != nil {
+		etsy.scopes = stored.Scopes
+	}
+	return stored.AccessToken != "" && time.Until(stored.Expiry) > expiryDelta
+}
+
+// setToken stores resp as the current token and reports it to Config.OnTokenRefresh
+func (etsy *EtsyClient) setToken(ctx context.Context, resp *oauth.AccessTokenResponse) error {
+	etsy.mu.Lock()
 	etsy.accessToken = resp.AccessToken
+	if resp.Scope != "" {
+		etsy.scopes = resp.Scopes() // keep the known scopes when Etsy does not report them
+	}
 	etsy.accessTokenExpiry = time.Now().UTC().Add(time.Duration(resp.ExpiresIn) * time.Second) //set expiration time
+	if resp.RefreshToken != "" {
+		etsy.refreshToken = resp.RefreshToken // Etsy rotates refresh tokens
+	}
+	token := etsy.tokenLocked()
+	etsy.mu.Unlock()
+
+	if etsy.cfg.OnTokenRefresh != nil {
+		return etsy.cfg.OnTokenRefresh(ctx, token)
+	}
 	return nil
 }
 
+// Token returns a copy of the current OAuth state, e.g. to persist it
+func (etsy *EtsyClient) Token() Token {
+	etsy.mu.Lock()
+	defer etsy.mu.Unlock()
+	return etsy.tokenLocked()
+}
+
+func (etsy *EtsyClient) tokenLocked() Token {
+	return Token{
+		AccessToken:  etsy.accessToken,
+		RefreshToken: etsy.refreshToken,
+		Expiry:       etsy.accessTokenExpiry,
+		Scopes:       etsy.scopes,
+	}
+}
+
+// Scopes returns the scopes granted to the current access token, or nil if unknown
+func (etsy *EtsyClient) Scopes() oauth.Scopes {
+	etsy.mu.Lock()
+	defer etsy.mu.Unlock()
+	return etsy.scopes
+}
+
 func (etsy *EtsyClient) ExchangeCodeForToken(code, codeVerifier string) error {
+	if etsy.public {
+		return ErrOAuthRequired
+	}
 	resp, err := etsy.cfg.OAuth.ExchangeCode(code, codeVerifier)
 	if err != nil {
 		return err
 	}
 
-	etsy.accessToken = resp.AccessToken
-	etsy.accessTokenExpiry = time.Now().UTC().Add(time.Duration(resp.ExpiresIn) * time.Second) //set expiration time
-	return nil
+	if err := etsy.lockRefresh(context.Background()); err != nil {
+		return err
+	}
+	defer etsy.unlockRefresh()
+	return etsy.setToken(context.Background(), resp)
 }
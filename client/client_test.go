@@ -0,0 +1,223 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/dzt-corp/go-etsy/oauth"
+)
+
+// tokenServer is a fake Etsy token endpoint that rotates refresh tokens strictly, like Etsy:
+// only the latest refresh token is accepted and every refresh invalidates it.
+type tokenServer struct {
+	*httptest.Server
+
+	mu            sync.Mutex
+	generation    int
+	expiresIn     int
+	refreshes     int
+	invalidGrants int
+	fail          error // while set, refreshes are answered with a 500
+	delay         time.Duration
+}
+
+func newTokenServer(t *testing.T, expiresIn int) *tokenServer {
+	t.Helper()
+	ts := &tokenServer{expiresIn: expiresIn}
+	ts.Server = httptest.NewServer(http.HandlerFunc(ts.serveHTTP))
+	t.Cleanup(ts.Close)
+	return ts
+}
+
+func (ts *tokenServer) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	ts.mu.Lock()
+	delay := ts.delay
+	ts.mu.Unlock()
+	time.Sleep(delay)
+
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	ts.refreshes++
+	if ts.fail != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		fmt.Fprint(w, ts.fail.Error())
+		return
+	}
+	if r.PostForm.Get("refresh_token") != ts.refreshTokenLocked() {
+		ts.invalidGrants++
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(w, `{"error":"invalid_grant","error_description":"refresh token revoked"}`)
+		return
+	}
+	ts.generation++
+	fmt.Fprintf(w, `{"access_token":"a%d","refresh_token":%q,"token_type":"Bearer","expires_in":%d}`,
+		ts.generation, ts.refreshTokenLocked(), ts.expiresIn)
+}
+
+func (ts *tokenServer) refreshTokenLocked() string {
+	return fmt.Sprintf("r%d", ts.generation)
+}
+
+func (ts *tokenServer) counts() (refreshes, invalidGrants int) {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	return ts.refreshes, ts.invalidGrants
+}
+
+func (ts *tokenServer) oauthClient() *oauth.OAuthClient {
+	c := oauth.NewOAuthClient("client-id", "http://localhost/callback")
+	c.TokenURL = ts.URL
+	return c
+}
+
+// newTestClient returns a client holding the token server's current refresh token and an expired access token
+func newTestClient(t *testing.T, ts *tokenServer) *EtsyClient {
+	t.Helper()
+	c, err := NewEtsyClient(&Config{APIKey: "key", RefreshToken: "r0", OAuth: ts.oauthClient()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+// flakyStore is a MemoryTokenStore whose first failSaves calls to Save fail
+type flakyStore struct {
+	*MemoryTokenStore
+	mu        sync.Mutex
+	failSaves int
+	loads     int
+}
+
+func (s *flakyStore) Load(ctx context.Context, tenantID string) (Token, error) {
+	s.mu.Lock()
+	s.loads++
+	s.mu.Unlock()
+	return s.MemoryTokenStore.Load(ctx, tenantID)
+}
+
+func (s *flakyStore) Save(ctx context.Context, tenantID string, token Token) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.failSaves > 0 {
+		s.failSaves--
+		return errors.New("db down")
+	}
+	return s.MemoryTokenStore.Save(ctx, tenantID, token)
+}
+
+func TestValidTokenRefreshesOnce(t *testing.T) {
+	ts := newTokenServer(t, 3600)
+	ts.delay = 50 * time.Millisecond
+	c := newTestClient(t, ts)
+
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, _, err := c.validToken(context.Background()); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if refreshes, _ := ts.counts(); refreshes != 1 {
+		t.Errorf("%d refreshes, want 1", refreshes)
+	}
+	if token := c.Token(); token.AccessToken != "a1" || token.RefreshToken != "r1" {
+		t.Errorf("got %+v", token)
+	}
+}
+
+func TestRefreshInvalidGrant(t *testing.T) {
+	ts := newTokenServer(t, 3600)
+	c, err := NewEtsyClient(&Config{APIKey: "key", RefreshToken: "revoked", OAuth: ts.oauthClient()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := c.RefreshToken(); !errors.Is(err, ErrReauthorizationRequired) || !oauth.IsInvalidGrant(err) {
+		t.Errorf("got %v, want ErrReauthorizationRequired wrapping invalid_grant", err)
+	}
+}
+
+func TestRefreshSurvivesFailedSave(t *testing.T) {
+	ts := newTokenServer(t, 3600)
+	store := &flakyStore{MemoryTokenStore: NewMemoryTokenStore()}
+	store.Save(context.Background(), "t", Token{AccessToken: "a0", RefreshToken: "r0", Expiry: time.Now().Add(-time.Hour)})
+	store.failSaves = 1
+
+	m, err := NewManager(ManagerConfig{APIKey: "key", OAuth: ts.oauthClient(), Store: store})
+	if err != nil {
+		t.Fatal(err)
+	}
+	c, err := m.Client(context.Background(), "t")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Etsy rotated r0 to r1 but the store still holds r0; the client must keep r1.
+	if err := c.RefreshToken(); err == nil || !strings.Contains(err.Error(), "db down") {
+		t.Fatalf("got %v, want the Save error", err)
+	}
+	if got := c.Token().RefreshToken; got != "r1" {
+		t.Fatalf("client holds %q after the failed save, want r1", got)
+	}
+
+	for range 2 {
+		if err := c.RefreshToken(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, invalidGrants := ts.counts(); invalidGrants != 0 {
+		t.Errorf("%d invalid_grant responses, want 0", invalidGrants)
+	}
+	if stored, _ := store.Load(context.Background(), "t"); stored.RefreshToken != "r3" {
+		t.Errorf("stored %q, want r3", stored.RefreshToken)
+	}
+}
+
+func TestRefreshAdoptsNewerStoredToken(t *testing.T) {
+	ts := newTokenServer(t, 3600)
+	store := NewMemoryTokenStore()
+	store.Save(context.Background(), "t", Token{RefreshToken: "r0"})
+
+	m, err := NewManager(ManagerConfig{APIKey: "key", OAuth: ts.oauthClient(), Store: store})
+	if err != nil {
+		t.Fatal(err)
+	}
+	stale, err := m.Client(context.Background(), "t")
+	if err != nil {
+		t.Fatal(err)
+	}
+	m.Evict("t")
+	fresh, err := m.Client(context.Background(), "t")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// fresh rotates r0 away; stale must pick up r1 from the store instead of spending r0.
+	if err := fresh.RefreshToken(); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := stale.validToken(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if refreshes, invalidGrants := ts.counts(); refreshes != 1 || invalidGrants != 0 {
+		t.Errorf("%d refreshes, %d invalid_grant; want 1, 0", refreshes, invalidGrants)
+	}
+	if got := stale.Token().AccessToken; got != "a1" {
+		t.Errorf("stale client uses %q, want the stored a1", got)
+	}
+}
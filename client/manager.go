@@ -0,0 +1,254 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/dzt-corp/go-etsy/oauth"
+)
+
+// buildTimeout bounds loading a tenant's token when building its client; see Manager.Client
+const buildTimeout = 30 * time.Second
+
+// ErrTenantNotFound is returned by TokenStore.Load when no token is stored for the tenant
+var ErrTenantNotFound = errors.New("tenant not found")
+
+// TokenStore persists the OAuth token of each tenant (seller)
+type TokenStore interface {
+	// Load returns the stored token of tenantID, or ErrTenantNotFound
+	Load(ctx context.Context, tenantID string) (Token, error)
+	// Save stores the token of tenantID, replacing the previous one
+	Save(ctx context.Context, tenantID string, token Token) error
+}
+
+// MemoryTokenStore is an in-memory TokenStore, mainly for tests and single-process tools
+type MemoryTokenStore struct {
+	mu     sync.Mutex
+	tokens map[string]Token
+}
+
+func NewMemoryTokenStore() *MemoryTokenStore {
+	return &MemoryTokenStore{tokens: make(map[string]Token)}
+}
+
+func (s *MemoryTokenStore) Load(ctx context.Context, tenantID string) (Token, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	token, ok := s.tokens[tenantID]
+	if !ok {
+		return Token{}, ErrTenantNotFound
+	}
+	return token, nil
+}
+
+func (s *MemoryTokenStore) Save(ctx context.Context, tenantID string, token Token) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.tokens[tenantID] = token
+	return nil
+}
+
+// ManagerConfig configures a Manager
+type ManagerConfig struct {
+	APIKey string
+	OAuth  *oauth.OAuthClient
+	Store  TokenStore
+
+	// RateLimiter is shared by every tenant client, since Etsy limits requests per API key.
+	RateLimiter *RateLimiter
+	// MaxClients caps the number of cached clients; the least recently used is evicted. 0 means no cap.
+	MaxClients int
+	// IdleTimeout evicts clients that have not been used for this long. 0 disables idle eviction.
+	IdleTimeout time.Duration
+
+	BaseURL    string
+	HTTPClient *http.Client
+}
+
+// Manager lazily builds and caches one authorized EtsyClient per tenant.
+// It is safe for concurrent use; concurrent requests for the same tenant share one client.
+//
+// An evicted client keeps working for callers still holding it. All clients of a tenant share one
+// refresh lock and reload the stored token before refreshing, so they never spend the same rotated
+// refresh token twice.
+type Manager struct {
+	cfg ManagerConfig
+
+	mu      sync.Mutex
+	clients map[string]*managedClient
+	// refreshSems holds the refresh lock of every tenant built so far. Unlike clients it is never
+	// evicted, so an evicted client and its replacement share the lock.
+	refreshSems map[string]chan struct{}
+}
+
+type managedClient struct {
+	ready    chan struct{} // closed once client or err is set
+	client   *EtsyClient
+	err      error
+	lastUsed time.Time
+}
+
+func NewManager(cfg ManagerConfig) (*Manager, error) {
+	if cfg.APIKey == "" {
+		return nil, errors.New("API key is required")
+	}
+	if cfg.OAuth == nil {
+		return nil, errors.New("oauth2 client is required")
+	}
+	if cfg.Store == nil {
+		return nil, errors.New("token store is required")
+	}
+	return &Manager{cfg: cfg, clients: make(map[string]*managedClient), refreshSems: make(map[string]chan struct{})}, nil
+}
+
+// Client returns the client of tenantID, building it from the TokenStore on first use.
+// It stops waiting when ctx is done; the build itself is not tied to ctx, so concurrent callers
+// for the same tenant are not failed by the first caller's cancellation.
+func (m *Manager) Client(ctx context.Context, tenantID string) (*EtsyClient, error) {
+	m.mu.Lock()
+	m.evictIdleLocked(time.Now())
+	mc, ok := m.clients[tenantID]
+	if !ok {
+		mc = &managedClient{ready: make(chan struct{})}
+		m.clients[tenantID] = mc
+		go m.load(ctx, tenantID, mc)
+	}
+	m.mu.Unlock()
+
+	select {
+	case <-mc.ready:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	if mc.err != nil {
+		return nil, mc.err
+	}
+	m.mu.Lock()
+	mc.lastUsed = time.Now()
+	m.mu.Unlock()
+	return mc.client, nil
+}
+
+// load builds the client of mc with a context detached from ctx's cancellation (bounded by buildTimeout)
+// and closes mc.ready once done
+func (m *Manager) load(ctx context.Context, tenantID string, mc *managedClient) {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), buildTimeout)
+	defer cancel()
+	client, err := m.build(ctx, tenantID)
+
+	m.mu.Lock()
+	mc.client, mc.err, mc.lastUsed = client, err, time.Now()
+	if err != nil {
+		// Evict may have replaced the entry while building; only drop our own.
+		if m.clients[tenantID] == mc {
+			delete(m.clients, tenantID)
+		}
+	} else {
+		m.evictLRULocked(tenantID)
+	}
+	m.mu.Unlock()
+	close(mc.ready)
+}
+
+// Evict drops the cached client of tenantID and stops its background refresher;
+// the next Client call rebuilds it from the TokenStore
+func (m *Manager) Evict(tenantID string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.removeLocked(tenantID)
+}
+
+// Len returns the number of cached clients
+func (m *Manager) Len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return len(m.clients)
+}
+
+func (m *Manager) build(ctx context.Context, tenantID string) (*EtsyClient, error) {
+	token, err := m.cfg.Store.Load(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	store := m.cfg.Store
+	client, err := NewEtsyClient(&Config{
+		APIKey:            m.cfg.APIKey,
+		RefreshToken:      token.RefreshToken,
+		OAuth:             m.cfg.OAuth,
+		BaseURL:           m.cfg.BaseURL,
+		HTTPClient:        m.cfg.HTTPClient,
+		AccessToken:       token.AccessToken,
+		AccessTokenExpiry: token.Expiry,
+		Scopes:            token.Scopes,
+		RateLimiter:       m.cfg.RateLimiter,
+		OnTokenRefresh: func(ctx context.Context, token Token) error {
+			return store.Save(ctx, tenantID, token)
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	client.refreshSem = m.refreshSem(tenantID)
+	client.loadToken = func(ctx context.Context) (Token, error) {
+		return store.Load(ctx, tenantID)
+	}
+	return client, nil
+}
+
+// refreshSem returns the refresh lock shared by all clients of tenantID
+func (m *Manager) refreshSem(tenantID string) chan struct{} {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	sem, ok := m.refreshSems[tenantID]
+	if !ok {
+		sem = make(chan struct{}, 1)
+		m.refreshSems[tenantID] = sem
+	}
+	return sem
+}
+
+func (m *Manager) evictIdleLocked(now time.Time) {
+	if m.cfg.IdleTimeout <= 0 {
+		return
+	}
+	for id, mc := range m.clients {
+		if mc.client != nil && now.Sub(mc.lastUsed) > m.cfg.IdleTimeout {
+			m.removeLocked(id)
+		}
+	}
+}
+
+// evictLRULocked evicts the least recently used clients above MaxClients, never evicting keep
+func (m *Manager) evictLRULocked(keep string) {
+	if m.cfg.MaxClients <= 0 {
+		return
+	}
+	for len(m.clients) > m.cfg.MaxClients {
+		oldestID := ""
+		var oldest time.Time
+		for id, mc := range m.clients {
+			if id == keep || mc.client == nil {
+				continue
+			}
+			if oldestID == "" || mc.lastUsed.Before(oldest) {
+				oldestID, oldest = id, mc.lastUsed
+			}
+		}
+		if oldestID == "" {
+			return
+		}
+		m.removeLocked(oldestID)
+	}
+}
+
+// removeLocked drops the client of tenantID and stops its background refresher, if one was started
+func (m *Manager) removeLocked(tenantID string) {
+	if mc, ok := m.clients[tenantID]; ok && mc.client != nil {
+		mc.client.stopRefresher()
+	}
+	delete(m.clients, tenantID)
+}
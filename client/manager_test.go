@@ -0,0 +1,212 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// blockingStore is a MemoryTokenStore whose Load waits until release is closed
+type blockingStore struct {
+	*MemoryTokenStore
+	release chan struct{}
+	loads   atomic.Int32
+}
+
+func (s *blockingStore) Load(ctx context.Context, tenantID string) (Token, error) {
+	s.loads.Add(1)
+	select {
+	case <-s.release:
+	case <-ctx.Done():
+		return Token{}, ctx.Err()
+	}
+	return s.MemoryTokenStore.Load(ctx, tenantID)
+}
+
+func newTestManager(t *testing.T, store TokenStore, cfg ManagerConfig) *Manager {
+	t.Helper()
+	cfg.APIKey, cfg.Store = "key", store
+	if cfg.OAuth == nil {
+		cfg.OAuth = newTokenServer(t, 3600).oauthClient()
+	}
+	m, err := NewManager(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return m
+}
+
+func TestManagerClientCancelledCallerDoesNotFailWaiters(t *testing.T) {
+	store := &blockingStore{MemoryTokenStore: NewMemoryTokenStore(), release: make(chan struct{})}
+	store.Save(context.Background(), "t", Token{RefreshToken: "r0"})
+	m := newTestManager(t, store, ManagerConfig{})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	first := make(chan error, 1)
+	go func() {
+		_, err := m.Client(ctx, "t")
+		first <- err
+	}()
+	for m.Len() == 0 { // the first caller builds
+		time.Sleep(time.Millisecond)
+	}
+	waiter := make(chan error, 1)
+	go func() {
+		_, err := m.Client(context.Background(), "t")
+		waiter <- err
+	}()
+
+	time.Sleep(20 * time.Millisecond)
+	cancel()
+	if err := <-first; !errors.Is(err, context.Canceled) {
+		t.Errorf("first caller: got %v, want context.Canceled", err)
+	}
+	close(store.release)
+	if err := <-waiter; err != nil {
+		t.Errorf("waiter: got %v, want the client", err)
+	}
+}
+
+func TestManagerClientBuildsOnce(t *testing.T) {
+	store := &blockingStore{MemoryTokenStore: NewMemoryTokenStore(), release: make(chan struct{})}
+	store.Save(context.Background(), "t", Token{RefreshToken: "r0"})
+	m := newTestManager(t, store, ManagerConfig{})
+
+	clients := make([]*EtsyClient, 10)
+	var wg sync.WaitGroup
+	for i := range clients {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			c, err := m.Client(context.Background(), "t")
+			if err != nil {
+				t.Error(err)
+			}
+			clients[i] = c
+		}()
+	}
+	time.Sleep(20 * time.Millisecond)
+	close(store.release)
+	wg.Wait()
+
+	if n := store.loads.Load(); n != 1 {
+		t.Errorf("%d loads, want 1", n)
+	}
+	for _, c := range clients {
+		if c != clients[0] {
+			t.Fatal("callers got different clients")
+		}
+	}
+}
+
+func TestManagerEvictsLeastRecentlyUsed(t *testing.T) {
+	store := &flakyStore{MemoryTokenStore: NewMemoryTokenStore()}
+	for _, id := range []string{"a", "b", "c"} {
+		store.Save(context.Background(), id, Token{RefreshToken: "r0"})
+	}
+	m := newTestManager(t, store, ManagerConfig{MaxClients: 2})
+
+	get := func(id string) *EtsyClient {
+		t.Helper()
+		time.Sleep(time.Millisecond) // distinct lastUsed times
+		c, err := m.Client(context.Background(), id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return c
+	}
+	a := get("a")
+	b := get("b")
+	get("a") // b is now the least recently used
+	if err := b.StartRefresher(context.Background(), nil); err != nil {
+		t.Fatal(err)
+	}
+	get("c")
+
+	if m.Len() != 2 {
+		t.Errorf("%d cached clients, want 2", m.Len())
+	}
+	if running(b) {
+		t.Error("evicted client's refresher still running")
+	}
+	if get("a") != a {
+		t.Error("a was evicted instead of b")
+	}
+	loads := store.loads
+	if get("b") == b || store.loads != loads+1 {
+		t.Error("b was not rebuilt from the store")
+	}
+}
+
+func TestManagerEvictsIdleClients(t *testing.T) {
+	store := NewMemoryTokenStore()
+	store.Save(context.Background(), "a", Token{RefreshToken: "r0"})
+	store.Save(context.Background(), "b", Token{RefreshToken: "r0"})
+	m := newTestManager(t, store, ManagerConfig{IdleTimeout: 20 * time.Millisecond})
+
+	a, err := m.Client(context.Background(), "a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(40 * time.Millisecond)
+	if _, err := m.Client(context.Background(), "b"); err != nil {
+		t.Fatal(err)
+	}
+	if m.Len() != 1 {
+		t.Errorf("%d cached clients, want only b", m.Len())
+	}
+	if again, _ := m.Client(context.Background(), "a"); again == a {
+		t.Error("idle client a was not evicted")
+	}
+}
+
+func TestManagerEvictedClientSharesRefreshLock(t *testing.T) {
+	ts := newTokenServer(t, 3600)
+	ts.delay = 20 * time.Millisecond
+	store := NewMemoryTokenStore()
+	store.Save(context.Background(), "t", Token{AccessToken: "a0", RefreshToken: "r0", Expiry: time.Now().Add(-time.Hour)})
+	m := newTestManager(t, store, ManagerConfig{OAuth: ts.oauthClient()})
+
+	evicted, err := m.Client(context.Background(), "t")
+	if err != nil {
+		t.Fatal(err)
+	}
+	m.Evict("t")
+	replacement, err := m.Client(context.Background(), "t")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if evicted.refreshSem != replacement.refreshSem {
+		t.Fatal("evicted client and its replacement have different refresh locks")
+	}
+
+	var wg sync.WaitGroup
+	for i := range 10 {
+		c := evicted
+		if i%2 == 0 {
+			c = replacement
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, _, err := c.validToken(context.Background()); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if refreshes, invalidGrants := ts.counts(); refreshes != 1 || invalidGrants != 0 {
+		t.Errorf("%d refreshes, %d invalid_grant; want 1, 0", refreshes, invalidGrants)
+	}
+}
+
+// running reports whether the background refresher of c is running
+func running(c *EtsyClient) bool {
+	c.refresherMu.Lock()
+	defer c.refresherMu.Unlock()
+	return c.refresherCancel != nil
+}
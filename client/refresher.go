@@ -0,0 +1,131 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+const (
+	// refreshLead is how long before expiry the background refresher renews the token.
+	// It is larger than expiryDelta so requests never have to wait for a lazy refresh.
+	refreshLead = 2 * expiryDelta
+
+	// minRefreshInterval keeps tokens living shorter than refreshLead from being refreshed back-to-back
+	minRefreshInterval = 10 * time.Second
+
+	minRetryDelay = 5 * time.Second
+	maxRetryDelay = 1 * time.Minute
+)
+
+// ErrRefresherRunning is returned by StartRefresher when the refresher is already running
+var ErrRefresherRunning = errors.New("token refresher already running")
+
+// StartRefresher starts a goroutine that refreshes the access token shortly before it expires,
+// so requests do not pay for a refresh. Failed refreshes are reported to onError (which may be nil)
+// and retried with backoff, except ErrReauthorizationRequired which stops the refresher.
+// The refresher stops when ctx is cancelled or Close is called; it can then be started again.
+func (etsy *EtsyClient) StartRefresher(ctx context.Context, onError func(error)) error {
+	if etsy.public {
+		return ErrOAuthRequired
+	}
+
+	etsy.refresherMu.Lock()
+	defer etsy.refresherMu.Unlock()
+	if etsy.refresherCancel != nil {
+		return ErrRefresherRunning
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	done := make(chan struct{})
+	etsy.refresherCancel, etsy.refresherDone = cancel, done
+
+	go func() {
+		defer close(done)
+		defer cancel()
+		etsy.runRefresher(ctx, onError)
+
+		// Exited on its own (ctx cancelled or reauthorization required): allow a restart.
+		etsy.refresherMu.Lock()
+		if etsy.refresherDone == done {
+			etsy.refresherCancel, etsy.refresherDone = nil, nil
+		}
+		etsy.refresherMu.Unlock()
+	}()
+	return nil
+}
+
+// Close stops the background refresher, if any, and waits for it to exit
+func (etsy *EtsyClient) Close() error {
+	if done := etsy.stopRefresher(); done != nil {
+		<-done
+	}
+	return nil
+}
+
+// stopRefresher cancels the background refresher without waiting and returns a channel closed on its exit
+func (etsy *EtsyClient) stopRefresher() <-chan struct{} {
+	etsy.refresherMu.Lock()
+	defer etsy.refresherMu.Unlock()
+
+	if etsy.refresherCancel == nil {
+		return nil
+	}
+	etsy.refresherCancel()
+	done := etsy.refresherDone
+	etsy.refresherCancel, etsy.refresherDone = nil, nil
+	return done
+}
+
+func (etsy *EtsyClient) runRefresher(ctx context.Context, onError func(error)) {
+	timer := time.NewTimer(etsy.nextRefresh())
+	defer timer.Stop()
+
+	retryDelay := minRetryDelay
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer.C:
+		}
+
+		err := etsy.refreshIfDue(ctx)
+		if err == nil {
+			retryDelay = minRetryDelay
+			timer.Reset(max(etsy.nextRefresh(), minRefreshInterval))
+			continue
+		}
+		if ctx.Err() != nil {
+			return
+		}
+		if onError != nil {
+			onError(err)
+		}
+		if errors.Is(err, ErrReauthorizationRequired) {
+			return
+		}
+		timer.Reset(retryDelay)
+		retryDelay = min(retryDelay*2, maxRetryDelay)
+	}
+}
+
+// nextRefresh returns how long to wait until the token is within refreshLead of expiring
+func (etsy *EtsyClient) nextRefresh() time.Duration {
+	etsy.mu.Lock()
+	defer etsy.mu.Unlock()
+
+	if etsy.accessToken == "" || etsy.accessTokenExpiry.IsZero() {
+		return 0
+	}
+	return max(time.Until(etsy.accessTokenExpiry.Add(-refreshLead)), 0)
+}
+
+// refreshIfDue refreshes the token unless a request refreshed it while the refresher was waiting
+func (etsy *EtsyClient) refreshIfDue(ctx context.Context) error {
+	return etsy.runLocked(ctx, func(ctx context.Context) error {
+		if etsy.nextRefresh() > 0 {
+			return nil
+		}
+		return etsy.refresh(ctx)
+	})
+}
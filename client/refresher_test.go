@@ -0,0 +1,146 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+// newExpiringClient returns a client whose access token is due for a background refresh after due
+func newExpiringClient(t *testing.T, ts *tokenServer, refreshToken string, due time.Duration) *EtsyClient {
+	t.Helper()
+	c, err := NewEtsyClient(&Config{
+		APIKey:            "key",
+		RefreshToken:      refreshToken,
+		OAuth:             ts.oauthClient(),
+		AccessToken:       "a0",
+		AccessTokenExpiry: time.Now().Add(refreshLead + due),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { c.Close() })
+	return c
+}
+
+// errorRecorder collects the errors passed to a refresher's onError
+type errorRecorder struct {
+	mu   sync.Mutex
+	errs []error
+}
+
+func (r *errorRecorder) onError(err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.errs = append(r.errs, err)
+}
+
+func (r *errorRecorder) errors() []error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]error(nil), r.errs...)
+}
+
+// eventually fails t unless cond becomes true within a second
+func eventually(t *testing.T, what string, cond func() bool) {
+	t.Helper()
+	for deadline := time.Now().Add(time.Second); !cond(); time.Sleep(5 * time.Millisecond) {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", what)
+		}
+	}
+}
+
+func TestRefresherRefreshesBeforeExpiry(t *testing.T) {
+	ts := newTokenServer(t, 3600)
+	c := newExpiringClient(t, ts, "r0", 50*time.Millisecond)
+	var rec errorRecorder
+	if err := c.StartRefresher(context.Background(), rec.onError); err != nil {
+		t.Fatal(err)
+	}
+
+	if refreshes, _ := ts.counts(); refreshes != 0 {
+		t.Fatalf("%d refreshes before the token was due", refreshes)
+	}
+	eventually(t, "the refresh", func() bool { return c.Token().AccessToken == "a1" })
+
+	// The new token lives an hour, so nothing else is due.
+	time.Sleep(50 * time.Millisecond)
+	if refreshes, _ := ts.counts(); refreshes != 1 {
+		t.Errorf("%d refreshes, want 1", refreshes)
+	}
+	if errs := rec.errors(); len(errs) != 0 {
+		t.Errorf("onError called with %v", errs)
+	}
+}
+
+func TestRefresherBacksOffOnError(t *testing.T) {
+	ts := newTokenServer(t, 3600)
+	ts.fail = errors.New("unavailable")
+	c := newExpiringClient(t, ts, "r0", 0)
+	var rec errorRecorder
+	if err := c.StartRefresher(context.Background(), rec.onError); err != nil {
+		t.Fatal(err)
+	}
+
+	eventually(t, "onError", func() bool { return len(rec.errors()) == 1 })
+	// The retry waits minRetryDelay instead of hammering the token endpoint.
+	time.Sleep(100 * time.Millisecond)
+	if refreshes, _ := ts.counts(); refreshes != 1 {
+		t.Errorf("%d refreshes within 100ms, want 1 before backing off", refreshes)
+	}
+	if errs := rec.errors(); len(errs) != 1 || errors.Is(errs[0], ErrReauthorizationRequired) {
+		t.Errorf("got %v, want one temporary error", errs)
+	}
+	if !running(c) {
+		t.Error("refresher stopped after a temporary error")
+	}
+}
+
+func TestRefresherStopsOnReauthorizationRequired(t *testing.T) {
+	ts := newTokenServer(t, 3600)
+	c := newExpiringClient(t, ts, "revoked", 0)
+	var rec errorRecorder
+	if err := c.StartRefresher(context.Background(), rec.onError); err != nil {
+		t.Fatal(err)
+	}
+
+	eventually(t, "the refresher to stop", func() bool { return !running(c) })
+	if errs := rec.errors(); len(errs) != 1 || !errors.Is(errs[0], ErrReauthorizationRequired) {
+		t.Errorf("got %v, want ErrReauthorizationRequired", errs)
+	}
+	if refreshes, _ := ts.counts(); refreshes != 1 {
+		t.Errorf("%d refreshes, want 1", refreshes)
+	}
+}
+
+func TestRefresherRestartsAfterClose(t *testing.T) {
+	ts := newTokenServer(t, 3600)
+	c := newExpiringClient(t, ts, "r0", time.Hour)
+
+	if err := c.StartRefresher(context.Background(), nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.StartRefresher(context.Background(), nil); !errors.Is(err, ErrRefresherRunning) {
+		t.Errorf("second start: got %v, want ErrRefresherRunning", err)
+	}
+	if err := c.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if running(c) {
+		t.Fatal("refresher still running after Close")
+	}
+	if err := c.StartRefresher(context.Background(), nil); err != nil {
+		t.Errorf("restart after Close: %v", err)
+	}
+
+	public, err := NewPublicEtsyClient("key")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := public.StartRefresher(context.Background(), nil); !errors.Is(err, ErrOAuthRequired) {
+		t.Errorf("public client: got %v, want ErrOAuthRequired", err)
+	}
+}
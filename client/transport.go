@@ -0,0 +1,91 @@
+package client
+
+import (
+	"io"
+	"net/http"
+	"strings"
+)
+
+// Transport is an http.RoundTripper that adds the API key and a valid access token of an EtsyClient
+// to every request. On a 401 it forces a token refresh and retries the request once.
+//
+// Use it instead of, not together with, WithRequestBefore(etsy.RequestBefore).
+type Transport struct {
+	Client *EtsyClient
+	// Base performs the requests. Defaults to http.DefaultTransport.
+	Base http.RoundTripper
+}
+
+// Transport returns a Transport authorizing requests with etsy on top of base (nil for http.DefaultTransport)
+func (etsy *EtsyClient) Transport(base http.RoundTripper) *Transport {
+	return &Transport{Client: etsy, Base: base}
+}
+
+// HTTPClient returns an *http.Client authorizing its requests with etsy. It can be passed as the
+// HttpRequestDoer of the listing, receipt and shop clients or to any other library.
+func (etsy *EtsyClient) HTTPClient() *http.Client {
+	return &http.Client{Transport: etsy.Transport(nil)}
+}
+
+func (t *Transport) base() http.RoundTripper {
+	if t.Base != nil {
+		return t.Base
+	}
+	return http.DefaultTransport
+}
+
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	authReq, err := t.authorize(req)
+	if err != nil {
+		closeBody(req)
+		return nil, err
+	}
+
+	rsp, err := t.base().RoundTrip(authReq)
+	if err != nil || rsp.StatusCode != http.StatusUnauthorized || t.Client.IsPublic() {
+		return rsp, err
+	}
+
+	// The body was consumed by the first attempt; only retry if it can be replayed.
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return rsp, nil
+	}
+	io.Copy(io.Discard, rsp.Body)
+	rsp.Body.Close()
+
+	usedToken := strings.TrimPrefix(authReq.Header.Get("Authorization"), "Bearer ")
+	if err := t.Client.refreshIfCurrent(req.Context(), usedToken); err != nil {
+		closeBody(req)
+		return nil, err
+	}
+
+	retryReq, err := t.authorize(req)
+	if err != nil {
+		closeBody(req)
+		return nil, err
+	}
+	if req.GetBody != nil {
+		if retryReq.Body, err = req.GetBody(); err != nil {
+			closeBody(req)
+			return nil, err
+		}
+	}
+	return t.base().RoundTrip(retryReq)
+}
+
+// authorize returns a copy of req with fresh auth headers; a RoundTripper must not modify req itself
+func (t *Transport) authorize(req *http.Request) (*http.Request, error) {
+	r := req.Clone(req.Context())
+	r.Header.Del("x-api-key")
+	r.Header.Del("Authorization")
+	if err := t.Client.AuthorizeRequest(r); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func closeBody(req *http.Request) {
+	if req.Body != nil {
+		req.Body.Close()
+	}
+}
@@ -0,0 +1,151 @@
+package client
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// apiServer accepts only the given access token and records every request body it receives
+type apiServer struct {
+	*httptest.Server
+
+	mu     sync.Mutex
+	token  string
+	bodies []string
+}
+
+func newAPIServer(t *testing.T, token string) *apiServer {
+	t.Helper()
+	s := &apiServer{token: token}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		s.mu.Lock()
+		s.bodies = append(s.bodies, string(body))
+		s.mu.Unlock()
+		if r.Header.Get("x-api-key") != "key" || (s.token != "" && r.Header.Get("Authorization") != "Bearer "+s.token) {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		io.WriteString(w, "ok")
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *apiServer) requests() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.bodies...)
+}
+
+// newRevokedClient returns a client whose access token looks valid but was revoked by Etsy
+func newRevokedClient(t *testing.T, ts *tokenServer) *EtsyClient {
+	t.Helper()
+	c := newTestClient(t, ts)
+	c.accessToken, c.accessTokenExpiry = "revoked", time.Now().Add(time.Hour)
+	return c
+}
+
+func TestTransportRetriesUnauthorizedOnce(t *testing.T) {
+	ts := newTokenServer(t, 3600)
+	api := newAPIServer(t, "a1")
+	c := newRevokedClient(t, ts)
+
+	rsp, err := c.HTTPClient().Post(api.URL, "text/plain", strings.NewReader("payload"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	rsp.Body.Close()
+
+	if rsp.StatusCode != http.StatusOK {
+		t.Errorf("status %d, want 200 after the retry", rsp.StatusCode)
+	}
+	if got := api.requests(); len(got) != 2 || got[0] != "payload" || got[1] != "payload" {
+		t.Errorf("server received %q, want the body twice", got)
+	}
+	if refreshes, _ := ts.counts(); refreshes != 1 {
+		t.Errorf("%d refreshes, want 1", refreshes)
+	}
+}
+
+func TestTransportDoesNotRetryUnreplayableBody(t *testing.T) {
+	ts := newTokenServer(t, 3600)
+	api := newAPIServer(t, "a1")
+	c := newRevokedClient(t, ts)
+
+	req, err := http.NewRequest("POST", api.URL, io.NopCloser(strings.NewReader("payload")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	rsp, err := c.HTTPClient().Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rsp.Body.Close()
+
+	if rsp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("status %d, want the 401 returned", rsp.StatusCode)
+	}
+	if got := api.requests(); len(got) != 1 {
+		t.Errorf("server received %d requests, want 1", len(got))
+	}
+	if refreshes, _ := ts.counts(); refreshes != 0 {
+		t.Errorf("%d refreshes, want 0", refreshes)
+	}
+}
+
+func TestTransportConcurrentUnauthorizedRefreshOnce(t *testing.T) {
+	ts := newTokenServer(t, 3600)
+	ts.delay = 20 * time.Millisecond
+	api := newAPIServer(t, "a1")
+	c := newRevokedClient(t, ts)
+	httpClient := c.HTTPClient()
+
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			rsp, err := httpClient.Get(api.URL)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			rsp.Body.Close()
+			if rsp.StatusCode != http.StatusOK {
+				t.Errorf("status %d, want 200", rsp.StatusCode)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if refreshes, invalidGrants := ts.counts(); refreshes != 1 || invalidGrants != 0 {
+		t.Errorf("%d refreshes, %d invalid_grant; want 1, 0", refreshes, invalidGrants)
+	}
+}
+
+func TestTransportPublicClientDoesNotRetry(t *testing.T) {
+	api := newAPIServer(t, "")
+	c, err := NewPublicEtsyClient("other-key")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rsp, err := c.HTTPClient().Get(api.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rsp.Body.Close()
+
+	if rsp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("status %d, want 401", rsp.StatusCode)
+	}
+	if got := api.requests(); len(got) != 1 {
+		t.Errorf("server received %d requests, want 1", len(got))
+	}
+}
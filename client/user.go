@@ -0,0 +1,151 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/dzt-corp/go-etsy/oauth"
+	"github.com/dzt-corp/go-etsy/response"
+	"github.com/dzt-corp/go-etsy/shop"
+)
+
+// ErrNoShop is returned by ShopID when the token owner does not own an Etsy shop.
+var ErrNoShop = errors.New("token owner has no shop")
+
+// Me is the response of the getMe endpoint
+// https://developers.etsy.com/documentation/reference#operation/getMe
+type Me struct {
+	UserID int64 `json:"user_id"`
+	ShopID int64 `json:"shop_id"`
+}
+
+// UserID returns the numeric Etsy user ID of the token owner.
+// Etsy prefixes both access and refresh tokens with the user ID, e.g. "12345678.abcdef...".
+func (etsy *EtsyClient) UserID() (int64, error) {
+	token := etsy.Token()
+	if token.AccessToken != "" {
+		return parseTokenUserID(token.AccessToken)
+	}
+	return parseTokenUserID(token.RefreshToken)
+}
+
+// ShopID returns the shop ID of the token owner.
+// The value is resolved on first use via getMe (falling back to getShopByOwnerUserId) and cached.
+func (etsy *EtsyClient) ShopID(ctx context.Context) (int64, error) {
+	etsy.shopMu.Lock()
+	defer etsy.shopMu.Unlock()
+
+	if etsy.shopID != 0 {
+		return etsy.shopID, nil
+	}
+
+	me, err := etsy.GetMe(ctx)
+	if err != nil {
+		return 0, err
+	}
+	shopID := me.ShopID
+	if shopID == 0 {
+		s, err := etsy.GetShopByOwnerUserID(ctx, me.UserID)
+		if err != nil {
+			return 0, err
+		}
+		shopID = s.ShopID
+	}
+	if shopID == 0 {
+		return 0, ErrNoShop
+	}
+
+	etsy.shopID = shopID
+	return shopID, nil
+}
+
+// GetMe returns the user and shop IDs of the token owner
+// GET /v3/application/users/me
+func (etsy *EtsyClient) GetMe(ctx context.Context) (*Me, error) {
+	var me Me
+	ctx = oauth.WithOperation(ctx, oauth.Operation{Name: "getMe"})
+	if err := etsy.get(ctx, "users/me", &me); err != nil {
+		return nil, err
+	}
+	return &me, nil
+}
+
+// GetShopByOwnerUserID returns the shop owned by the given user
+// GET /v3/application/users/{user_id}/shops
+func (etsy *EtsyClient) GetShopByOwnerUserID(ctx context.Context, userID int64) (*shop.Shop, error) {
+	var s shop.Shop
+	ctx = oauth.WithOperation(ctx, oauth.Operation{Name: "getShopByOwnerUserId", Public: true})
+	if err := etsy.get(ctx, fmt.Sprintf("users/%d/shops", userID), &s); err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+func (etsy *EtsyClient) get(ctx context.Context, path string, dest interface{}) error {
+	baseURL := etsy.cfg.BaseURL
+	if baseURL == "" {
+		baseURL = defaultBaseURL
+	}
+	if !strings.HasSuffix(baseURL, "/") {
+		baseURL += "/"
+	}
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		return err
+	}
+	u, err = u.Parse(path)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", u.String(), nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("User-Agent", userAgent)
+	if err := etsy.AuthorizeRequest(req); err != nil {
+		return err
+	}
+
+	httpClient := etsy.cfg.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	rsp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer rsp.Body.Close()
+
+	if err := response.Capture(ctx, rsp); err != nil {
+		return err
+	}
+
+	body, err := io.ReadAll(rsp.Body)
+	if err != nil {
+		return err
+	}
+	if rsp.StatusCode >= 300 {
+		return fmt.Errorf("HTTP error: %s - Body: %s", rsp.Status, string(body))
+	}
+	return json.Unmarshal(body, dest)
+}
+
+func parseTokenUserID(token string) (int64, error) {
+	prefix, _, ok := strings.Cut(token, ".")
+	if !ok || prefix == "" {
+		return 0, errors.New("token is not prefixed with a user ID")
+	}
+	userID, err := strconv.ParseInt(prefix, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid user ID prefix in token: %w", err)
+	}
+	return userID, nil
+}
@@ -0,0 +1,119 @@
+// Package jsonextra keeps the members of a JSON object that a struct does not declare,
+// so response models stay forward compatible when Etsy adds fields.
+package jsonextra
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+var knownKeys sync.Map // reflect.Type -> map[string]bool (lower-cased keys)
+
+// Unknown returns the members of the JSON object data that do not map to a field of v,
+// which must be a struct or a pointer to one. Keys are matched case-insensitively, as encoding/json does.
+// It returns nil when every member is known.
+func Unknown(data []byte, v interface{}) (map[string]json.RawMessage, error) {
+	var all map[string]json.RawMessage
+	if err := json.Unmarshal(data, &all); err != nil {
+		return nil, err
+	}
+
+	known := keysOf(reflect.TypeOf(v))
+	var extra map[string]json.RawMessage
+	for key, value := range all {
+		if known[strings.ToLower(key)] {
+			continue
+		}
+		if extra == nil {
+			extra = make(map[string]json.RawMessage)
+		}
+		extra[key] = value
+	}
+	return extra, nil
+}
+
+// Merge adds the extra members to the JSON object known. Members already in known take precedence.
+func Merge(known []byte, extra map[string]json.RawMessage) ([]byte, error) {
+	if len(extra) == 0 || bytes.Equal(known, []byte("null")) {
+		return known, nil
+	}
+
+	var all map[string]json.RawMessage
+	if err := json.Unmarshal(known, &all); err != nil {
+		return nil, err
+	}
+	for key, value := range extra {
+		if _, ok := all[key]; !ok {
+			all[key] = value
+		}
+	}
+	return json.Marshal(all)
+}
+
+func keysOf(t reflect.Type) map[string]bool {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if keys, ok := knownKeys.Load(t); ok {
+		return keys.(map[string]bool)
+	}
+
+	keys := make(map[string]bool)
+	collectKeys(t, keys)
+	knownKeys.Store(t, keys)
+	return keys
+}
+
+func collectKeys(t reflect.Type, keys map[string]bool) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, _, _ := strings.Cut(tag, ",")
+		if f.Anonymous && name == "" {
+			ft := f.Type
+			if ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				collectKeys(ft, keys)
+				continue
+			}
+		}
+		if !f.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		keys[strings.ToLower(name)] = true
+	}
+}
+
+// UnmarshalInto decodes data into plain, a pointer to a method-free copy of the model type,
+// and stores the members plain does not declare into extra.
+func UnmarshalInto(data []byte, plain interface{}, extra *map[string]json.RawMessage) error {
+	if err := json.Unmarshal(data, plain); err != nil {
+		return err
+	}
+	unknown, err := Unknown(data, plain)
+	if err != nil {
+		return err
+	}
+	*extra = unknown
+	return nil
+}
+
+// MarshalWith encodes plain, a method-free copy of the model, and adds the extra members back.
+func MarshalWith(plain interface{}, extra map[string]json.RawMessage) ([]byte, error) {
+	data, err := json.Marshal(plain)
+	if err != nil {
+		return nil, err
+	}
+	return Merge(data, extra)
+}
@@ -0,0 +1,100 @@
+package jsonextra
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+type embedded struct {
+	ShopID int64 `json:"shop_id"`
+}
+
+type model struct {
+	embedded
+	ListingID int64  `json:"listing_id"`
+	Title     string `json:"title,omitempty"`
+	NoTag     string
+	Ignored   string `json:"-"`
+	private   string
+
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+func (m *model) UnmarshalJSON(data []byte) error {
+	type plain model
+	return UnmarshalInto(data, (*plain)(m), &m.Extra)
+}
+
+func (m model) MarshalJSON() ([]byte, error) {
+	type plain model
+	return MarshalWith(plain(m), m.Extra)
+}
+
+func TestUnknown(t *testing.T) {
+	data := []byte(`{"listing_id":1,"TITLE":"t","shop_id":2,"notag":"x","Ignored":"y","private":"z","new_field":[1,2]}`)
+
+	extra, err := Unknown(data, &model{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]string{"Ignored": `"y"`, "private": `"z"`, "new_field": `[1,2]`}
+	if len(extra) != len(want) {
+		t.Fatalf("got %d unknown members %v, want %v", len(extra), extra, want)
+	}
+	for key, value := range want {
+		if string(extra[key]) != value {
+			t.Errorf("extra[%q] = %s, want %s", key, extra[key], value)
+		}
+	}
+
+	extra, err = Unknown([]byte(`{"listing_id":1}`), model{})
+	if err != nil || extra != nil {
+		t.Errorf("all members known: got %v, %v, want nil", extra, err)
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	var m model
+	if err := json.Unmarshal([]byte(`{"listing_id":1,"title":"t","new_field":{"a":1}}`), &m); err != nil {
+		t.Fatal(err)
+	}
+	if m.ListingID != 1 || m.Title != "t" || string(m.Extra["new_field"]) != `{"a":1}` {
+		t.Fatalf("decoded %+v", m)
+	}
+
+	data, err := json.Marshal(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got map[string]json.RawMessage
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatal(err)
+	}
+	if string(got["new_field"]) != `{"a":1}` || string(got["listing_id"]) != `1` {
+		t.Errorf("encoded %s", data)
+	}
+}
+
+func TestMergePrecedence(t *testing.T) {
+	data, err := Merge([]byte(`{"title":"declared"}`), map[string]json.RawMessage{
+		"title": json.RawMessage(`"extra"`),
+		"other": json.RawMessage(`true`),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got map[string]json.RawMessage
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatal(err)
+	}
+	if string(got["title"]) != `"declared"` || string(got["other"]) != `true` {
+		t.Errorf("merged %s", data)
+	}
+
+	for _, known := range []string{`null`, `{"a":1}`} {
+		out, err := Merge([]byte(known), nil)
+		if err != nil || string(out) != known {
+			t.Errorf("Merge(%s, nil) = %s, %v", known, out, err)
+		}
+	}
+}
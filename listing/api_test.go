@@ -0,0 +1,122 @@
+package listing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+// fakeListingServer serves listing 9 with the given JSON and image count and records the state PATCHed to it
+func fakeListingServer(t *testing.T, listingJSON string, images int, patched *string) *Client {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "GET" && r.URL.Path == "/v3/application/listings/9":
+			fmt.Fprint(w, listingJSON)
+		case r.Method == "GET" && r.URL.Path == "/v3/application/listings/9/images":
+			fmt.Fprintf(w, `{"count":%d,"results":[]}`, images)
+		case r.Method == "PATCH" && r.URL.Path == "/v3/application/shops/1/listings/9":
+			if err := r.ParseForm(); err != nil {
+				t.Fatal(err)
+			}
+			*patched = r.PostForm.Get("state")
+			fmt.Fprintf(w, `{"listing_id":9,"state":%q}`, *patched)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	c, err := NewClient(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+func TestTransitions(t *testing.T) {
+	ready := func(state State) string {
+		return fmt.Sprintf(`{"listing_id":9,"state":%q,"quantity":3,"shipping_profile_id":5,"listing_type":"physical"}`, state)
+	}
+	type transitionFn func(*Client, context.Context, int64, int64) (*Listing, error)
+
+	tests := []struct {
+		name    string
+		fn      transitionFn
+		listing string
+		images  int
+		want    State
+		wantErr *TransitionError
+	}{
+		{"publish", (*Client).Publish, ready(StateDraft), 1, StateActive, nil},
+		{"deactivate", (*Client).Deactivate, ready(StateActive), 0, StateInactive, nil},
+		{"reactivate", (*Client).Reactivate, ready(StateInactive), 1, StateActive, nil},
+		{"renew", (*Client).Renew, ready(StateExpired), 1, StateActive, nil},
+		{
+			name: "digital publish needs no shipping profile", fn: (*Client).Publish,
+			listing: `{"listing_id":9,"state":"draft","quantity":1,"listing_type":"download"}`, images: 1, want: StateActive,
+		},
+		{
+			name: "publish incomplete draft", fn: (*Client).Publish,
+			listing: `{"listing_id":9,"state":"draft","quantity":0,"listing_type":"physical"}`,
+			wantErr: &TransitionError{ListingID: 9, Action: "publish", State: StateDraft,
+				Missing: []string{"quantity greater than 0", "shipping profile", "at least one image"}},
+		},
+		{
+			name: "deactivate inactive", fn: (*Client).Deactivate, listing: ready(StateInactive),
+			wantErr: &TransitionError{ListingID: 9, Action: "deactivate", State: StateInactive,
+				Missing: []string{"state active (listing is inactive)"}},
+		},
+		{
+			name: "reactivate draft", fn: (*Client).Reactivate, listing: ready(StateDraft), images: 1,
+			wantErr: &TransitionError{ListingID: 9, Action: "reactivate", State: StateDraft,
+				Missing: []string{"state inactive (listing is draft)"}},
+		},
+		{
+			name: "renew sold out", fn: (*Client).Renew,
+			listing: `{"listing_id":9,"state":"sold_out","quantity":0,"shipping_profile_id":5,"listing_type":"physical"}`, images: 1,
+			wantErr: &TransitionError{ListingID: 9, Action: "renew", State: StateSoldOut,
+				Missing: []string{"state expired (listing is sold_out)", "quantity greater than 0"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var patched string
+			c := fakeListingServer(t, tt.listing, tt.images, &patched)
+
+			listing, err := tt.fn(c, context.Background(), 1, 9)
+			if tt.wantErr != nil {
+				var terr *TransitionError
+				if !errors.As(err, &terr) {
+					t.Fatalf("got %v, want *TransitionError", err)
+				}
+				if !reflect.DeepEqual(terr, tt.wantErr) {
+					t.Errorf("got %+v, want %+v", terr, tt.wantErr)
+				}
+				if patched != "" {
+					t.Errorf("listing was PATCHed to %q despite the error", patched)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if patched != string(tt.want) || listing.State != tt.want {
+				t.Errorf("PATCHed %q, returned %q, want %q", patched, listing.State, tt.want)
+			}
+		})
+	}
+}
+
+func TestTransitionErrorMessage(t *testing.T) {
+	err := &TransitionError{ListingID: 9, Action: "publish", Missing: []string{"shipping profile", "at least one image"}}
+	if got, want := err.Error(), "cannot publish listing 9: requires shipping profile, at least one image"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
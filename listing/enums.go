@@ -0,0 +1,178 @@
+package listing
+
+import (
+	"errors"
+	"fmt"
+)
+
+// State is the state of a listing
+type State string
+
+const (
+	StateActive   State = "active"
+	StateInactive State = "inactive"
+	StateDraft    State = "draft"
+	StateExpired  State = "expired"
+	StateSoldOut  State = "sold_out"
+	StateEdit     State = "edit"
+)
+
+func (s State) Valid() bool {
+	switch s {
+	case StateActive, StateInactive, StateDraft, StateExpired, StateSoldOut, StateEdit:
+		return true
+	}
+	return false
+}
+
+// WhoMade tells who made the product being sold
+type WhoMade string
+
+const (
+	WhoMadeIDid        WhoMade = "i_did"
+	WhoMadeSomeoneElse WhoMade = "someone_else"
+	WhoMadeCollective  WhoMade = "collective"
+)
+
+func (w WhoMade) Valid() bool {
+	switch w {
+	case WhoMadeIDid, WhoMadeSomeoneElse, WhoMadeCollective:
+		return true
+	}
+	return false
+}
+
+// WhenMade is the era in which the maker made the product
+type WhenMade string
+
+const (
+	WhenMadeToOrder    WhenMade = "made_to_order"
+	WhenMade2020_2025  WhenMade = "2020_2025"
+	WhenMade2010_2019  WhenMade = "2010_2019"
+	WhenMade2006_2009  WhenMade = "2006_2009"
+	WhenMadeBefore2006 WhenMade = "before_2006"
+	WhenMade2000_2005  WhenMade = "2000_2005"
+	WhenMade1990s      WhenMade = "1990s"
+	WhenMade1980s      WhenMade = "1980s"
+	WhenMade1970s      WhenMade = "1970s"
+	WhenMade1960s      WhenMade = "1960s"
+	WhenMade1950s      WhenMade = "1950s"
+	WhenMade1940s      WhenMade = "1940s"
+	WhenMade1930s      WhenMade = "1930s"
+	WhenMade1920s      WhenMade = "1920s"
+	WhenMade1910s      WhenMade = "1910s"
+	WhenMade1900s      WhenMade = "1900s"
+	WhenMade1800s      WhenMade = "1800s"
+	WhenMade1700s      WhenMade = "1700s"
+	WhenMadeBefore1700 WhenMade = "before_1700"
+)
+
+func (w WhenMade) Valid() bool {
+	switch w {
+	case WhenMadeToOrder, WhenMade2020_2025, WhenMade2010_2019, WhenMade2006_2009, WhenMadeBefore2006,
+		WhenMade2000_2005, WhenMade1990s, WhenMade1980s, WhenMade1970s, WhenMade1960s, WhenMade1950s,
+		WhenMade1940s, WhenMade1930s, WhenMade1920s, WhenMade1910s, WhenMade1900s, WhenMade1800s,
+		WhenMade1700s, WhenMadeBefore1700:
+		return true
+	}
+	return false
+}
+
+// ListingType is the type of product a listing sells
+type ListingType string
+
+const (
+	ListingTypePhysical ListingType = "physical"
+	ListingTypeDownload ListingType = "download"
+	ListingTypeBoth     ListingType = "both"
+)
+
+func (t ListingType) Valid() bool {
+	switch t {
+	case ListingTypePhysical, ListingTypeDownload, ListingTypeBoth:
+		return true
+	}
+	return false
+}
+
+// SortOn is the field listing collections are sorted by
+type SortOn string
+
+const (
+	SortOnCreated SortOn = "created"
+	SortOnPrice   SortOn = "price"
+	SortOnUpdated SortOn = "updated"
+	SortOnScore   SortOn = "score"
+)
+
+func (s SortOn) Valid() bool {
+	switch s {
+	case SortOnCreated, SortOnPrice, SortOnUpdated, SortOnScore:
+		return true
+	}
+	return false
+}
+
+// SortOrder is the direction listing collections are sorted in
+type SortOrder string
+
+const (
+	SortOrderAsc        SortOrder = "asc"
+	SortOrderAscending  SortOrder = "ascending"
+	SortOrderDesc       SortOrder = "desc"
+	SortOrderDescending SortOrder = "descending"
+	SortOrderUp         SortOrder = "up"
+	SortOrderDown       SortOrder = "down"
+)
+
+func (s SortOrder) Valid() bool {
+	switch s {
+	case SortOrderAsc, SortOrderAscending, SortOrderDesc, SortOrderDescending, SortOrderUp, SortOrderDown:
+		return true
+	}
+	return false
+}
+
+// checkEnum returns an error if v is set to a value Etsy does not accept.
+// Empty values are left to the API, which either ignores or requires them.
+func checkEnum[T interface {
+	~string
+	Valid() bool
+}](field string, v T) error {
+	if v == "" || v.Valid() {
+		return nil
+	}
+	return fmt.Errorf("invalid %s %q", field, string(v))
+}
+
+func (p *GetListingsByShopParams) checkEnums() error {
+	if p == nil {
+		return nil
+	}
+	return errors.Join(
+		checkEnum("state", p.State),
+		checkEnum("sort_on", p.SortOn),
+		checkEnum("sort_order", p.SortOrder),
+	)
+}
+
+func (p *FindAllActiveListingsByShopParams) checkEnums() error {
+	if p == nil {
+		return nil
+	}
+	return errors.Join(checkEnum("sort_on", p.SortOn), checkEnum("sort_order", p.SortOrder))
+}
+
+func (p *FindAllListingsActiveParams) checkEnums() error {
+	if p == nil {
+		return nil
+	}
+	return errors.Join(checkEnum("sort_on", p.SortOn), checkEnum("sort_order", p.SortOrder))
+}
+
+func (p *GetListingsByShopSectionIdParams) checkEnums() error {
+	if p == nil {
+		return nil
+	}
+	return errors.Join(checkEnum("sort_on", p.SortOn), checkEnum("sort_order", p.SortOrder))
+}
@@ -0,0 +1,101 @@
+package listing
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestEnumValid(t *testing.T) {
+	type enum interface{ Valid() bool }
+	valid := []enum{
+		StateActive, StateInactive, StateDraft, StateExpired, StateSoldOut, StateEdit,
+		WhoMadeIDid, WhoMadeSomeoneElse, WhoMadeCollective,
+		WhenMadeToOrder, WhenMade2020_2025, WhenMade2010_2019, WhenMade2006_2009, WhenMadeBefore2006,
+		WhenMade2000_2005, WhenMade1990s, WhenMade1980s, WhenMade1970s, WhenMade1960s, WhenMade1950s,
+		WhenMade1940s, WhenMade1930s, WhenMade1920s, WhenMade1910s, WhenMade1900s, WhenMade1800s,
+		WhenMade1700s, WhenMadeBefore1700,
+		ListingTypePhysical, ListingTypeDownload, ListingTypeBoth,
+		SortOnCreated, SortOnPrice, SortOnUpdated, SortOnScore,
+		SortOrderAsc, SortOrderAscending, SortOrderDesc, SortOrderDescending, SortOrderUp, SortOrderDown,
+	}
+	for _, e := range valid {
+		if !e.Valid() {
+			t.Errorf("%T %q is not valid", e, e)
+		}
+	}
+
+	invalid := []enum{State("deleted"), WhoMade("robot"), WhenMade("2030_2035"), ListingType("service"),
+		SortOn("random"), SortOrder("sideways"), State(""), SortOn("Created")}
+	for _, e := range invalid {
+		if e.Valid() {
+			t.Errorf("%T %q is valid", e, e)
+		}
+	}
+}
+
+func TestCheckEnums(t *testing.T) {
+	tests := []struct {
+		name   string
+		check  func() error
+		errors []string
+	}{
+		{"nil params", (*GetListingsByShopParams)(nil).checkEnums, nil},
+		{"empty values", (&GetListingsByShopParams{}).checkEnums, nil},
+		{"valid values", (&GetListingsByShopParams{State: StateDraft, SortOn: SortOnPrice, SortOrder: SortOrderDesc}).checkEnums, nil},
+		{
+			"invalid by shop", (&GetListingsByShopParams{State: "gone", SortOn: "random", SortOrder: "sideways"}).checkEnums,
+			[]string{`invalid state "gone"`, `invalid sort_on "random"`, `invalid sort_order "sideways"`},
+		},
+		{"invalid active by shop", (&FindAllActiveListingsByShopParams{SortOn: "random"}).checkEnums, []string{`invalid sort_on "random"`}},
+		{"invalid active", (&FindAllListingsActiveParams{SortOrder: "sideways"}).checkEnums, []string{`invalid sort_order "sideways"`}},
+		{"invalid by section", (&GetListingsByShopSectionIdParams{SortOn: "random"}).checkEnums, []string{`invalid sort_on "random"`}},
+	}
+	for _, tt := range tests {
+		err := tt.check()
+		if len(tt.errors) == 0 {
+			if err != nil {
+				t.Errorf("%s: unexpected error %v", tt.name, err)
+			}
+			continue
+		}
+		if err == nil {
+			t.Errorf("%s: expected an error", tt.name)
+			continue
+		}
+		for _, want := range tt.errors {
+			if !strings.Contains(err.Error(), want) {
+				t.Errorf("%s: %q does not contain %q", tt.name, err, want)
+			}
+		}
+	}
+}
+
+func TestEnumChecksOnlyWithValidation(t *testing.T) {
+	requests := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		fmt.Fprint(w, `{"count":0,"results":[]}`)
+	}))
+	defer srv.Close()
+	params := &GetListingsByShopParams{SortOn: "newly_added_by_etsy"}
+
+	c, err := NewClient(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.GetListingsByShop(context.Background(), 1, params); err != nil || requests != 1 {
+		t.Errorf("without validation: err %v after %d requests, want the request sent", err, requests)
+	}
+
+	c, err = NewClient(srv.URL, WithValidation())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.GetListingsByShop(context.Background(), 1, params); err == nil || requests != 1 {
+		t.Errorf("with validation: err %v after %d requests, want an error before sending", err, requests)
+	}
+}
@@ -0,0 +1,38 @@
+package listing
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// APIError is returned when Etsy responds with a non-2xx status code
+type APIError struct {
+	StatusCode int
+	Status     string
+	Body       []byte
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("HTTP error: %s - Body: %s", e.Status, string(e.Body))
+}
+
+// IsNotFound reports whether err is an APIError with status 404
+func IsNotFound(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound
+}
+
+// TransitionError is returned by Publish, Deactivate, Reactivate and Renew
+// when the listing does not meet the prerequisites of the state change
+type TransitionError struct {
+	ListingID int64
+	Action    string
+	State     State
+	Missing   []string
+}
+
+func (e *TransitionError) Error() string {
+	return fmt.Sprintf("cannot %s listing %d: requires %s", e.Action, e.ListingID, strings.Join(e.Missing, ", "))
+}
@@ -0,0 +1,27 @@
+package listing
+
+import "github.com/dzt-corp/go-etsy/oauth"
+
+// Etsy operations of the listing API and the OAuth scopes they require.
+// Public operations only need the API key.
+var (
+	opCreateDraftListing           = oauth.Operation{Name: "createDraftListing", Scopes: oauth.Scopes{oauth.ScopeListingsWrite}}
+	opGetListing                   = oauth.Operation{Name: "getListing", Public: true}
+	opUpdateListing                = oauth.Operation{Name: "updateListing", Scopes: oauth.Scopes{oauth.ScopeListingsWrite}}
+	opDeleteListing                = oauth.Operation{Name: "deleteListing", Scopes: oauth.Scopes{oauth.ScopeListingsDelete}}
+	opGetListingsByShop            = oauth.Operation{Name: "getListingsByShop", Scopes: oauth.Scopes{oauth.ScopeListingsRead}}
+	opFindAllActiveListingsByShop  = oauth.Operation{Name: "findAllActiveListingsByShop", Public: true}
+	opFindAllListingsActive        = oauth.Operation{Name: "findAllListingsActive", Public: true}
+	opGetListingsByListingIds      = oauth.Operation{Name: "getListingsByListingIds", Public: true}
+	opGetListingsByShopSectionId   = oauth.Operation{Name: "getListingsByShopSectionId", Public: true}
+	opGetListingsByShopReceipt     = oauth.Operation{Name: "getListingsByShopReceipt", Scopes: oauth.Scopes{oauth.ScopeTransactionsRead}}
+	opGetListingImages             = oauth.Operation{Name: "getListingImages", Public: true}
+	opGetListingTranslation        = oauth.Operation{Name: "getListingTranslation", Public: true}
+	opCreateListingTranslation     = oauth.Operation{Name: "createListingTranslation", Scopes: oauth.Scopes{oauth.ScopeListingsWrite}}
+	opUpdateListingTranslation     = oauth.Operation{Name: "updateListingTranslation", Scopes: oauth.Scopes{oauth.ScopeListingsWrite}}
+	opGetListingVariationImages    = oauth.Operation{Name: "getListingVariationImages", Public: true}
+	opUpdateVariationImages        = oauth.Operation{Name: "updateVariationImages", Scopes: oauth.Scopes{oauth.ScopeListingsWrite}}
+	opGetListingPersonalization    = oauth.Operation{Name: "getListingPersonalization", Public: true}
+	opUpdateListingPersonalization = oauth.Operation{Name: "updateListingPersonalization", Scopes: oauth.Scopes{oauth.ScopeListingsWrite}}
+	opDeleteListingPersonalization = oauth.Operation{Name: "deleteListingPersonalization", Scopes: oauth.Scopes{oauth.ScopeListingsWrite}}
+)
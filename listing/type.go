@@ -1,47 +1,216 @@
 package listing
 
+import (
+	"encoding/json"
+
+	"github.com/dzt-corp/go-etsy/internal/jsonextra"
+	"github.com/dzt-corp/go-etsy/money"
+	"github.com/dzt-corp/go-etsy/shop"
+	"github.com/dzt-corp/go-etsy/timestamp"
+)
+
 // ==========================================
 // Structs & Models
 // ==========================================
 
+// Response models keep the JSON members they do not declare in Extra and write them back when marshalled,
+// so fields Etsy adds later survive a decode/encode round trip.
+
 // Listing represents the core listing object
 type Listing struct {
-	ListingID           int64    `json:"listing_id"`
-	UserID              int64    `json:"user_id"`
-	ShopID              int64    `json:"shop_id"`
-	Title               string   `json:"title"`
-	Description         string   `json:"description"`
-	State               string   `json:"state"`
-	CreationTsz         int64    `json:"creation_tsz"`
-	EndingTsz           int64    `json:"ending_tsz"`
-	OriginalCreationTsz int64    `json:"original_creation_tsz"`
-	LastModifiedTsz     int64    `json:"last_modified_tsz"`
-	Price               Amount   `json:"price"`
-	Quantity            int      `json:"quantity"`
-	Tags                []string `json:"tags"`
-	Materials           []string `json:"materials"`
-	ShopSectionID       int64    `json:"shop_section_id"`
-	FeaturedRank        int      `json:"featured_rank"`
-	Url                 string   `json:"url"`
-	Views               int      `json:"views"`
-	NumFavorers         int      `json:"num_favorers"`
-	WhoMade             string   `json:"who_made"` // enum: i_did, someone_else, collective
-	WhenMade            string   `json:"when_made"`
-	IsCustomizable      bool     `json:"is_customizable"`
-	IsPersonalizable    bool     `json:"is_personalizable"`
-	IsPrivate           bool     `json:"is_private"`
-	Style               []string `json:"style"`
-	FileData            string   `json:"file_data"`
-	HasVariations       bool     `json:"has_variations"`
-	ShouldAutoRenew     bool     `json:"should_auto_renew"`
-	Language            string   `json:"language"`
-	SKU                 []string `json:"sku"`
-}
-
-type Amount struct {
-	Amount       int    `json:"amount"`
-	Divisor      int    `json:"divisor"`
-	CurrencyCode string `json:"currency_code"`
+	ListingID   int64  `json:"listing_id"`
+	UserID      int64  `json:"user_id"`
+	ShopID      int64  `json:"shop_id"`
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	State       State  `json:"state"`
+	// Deprecated: Etsy v3 does not send *_tsz fields; use CreationTimestamp.
+	CreationTsz timestamp.Timestamp `json:"creation_tsz"`
+	// Deprecated: Etsy v3 does not send *_tsz fields; use EndingTimestamp.
+	EndingTsz timestamp.Timestamp `json:"ending_tsz"`
+	// Deprecated: Etsy v3 does not send *_tsz fields; use OriginalCreationTimestamp.
+	OriginalCreationTsz timestamp.Timestamp `json:"original_creation_tsz"`
+	// Deprecated: Etsy v3 does not send *_tsz fields; use LastModifiedTimestamp.
+	LastModifiedTsz  timestamp.Timestamp `json:"last_modified_tsz"`
+	Price            Amount              `json:"price"`
+	Quantity         int                 `json:"quantity"`
+	Tags             []string            `json:"tags"`
+	Materials        []string            `json:"materials"`
+	ShopSectionID    int64               `json:"shop_section_id"`
+	FeaturedRank     int                 `json:"featured_rank"`
+	Url              string              `json:"url"`
+	Views            int                 `json:"views"`
+	NumFavorers      int                 `json:"num_favorers"`
+	WhoMade          WhoMade             `json:"who_made"`
+	WhenMade         WhenMade            `json:"when_made"`
+	IsCustomizable   bool                `json:"is_customizable"`
+	IsPersonalizable bool                `json:"is_personalizable"`
+	IsPrivate        bool                `json:"is_private"`
+	Style            []string            `json:"style"`
+	FileData         string              `json:"file_data"`
+	HasVariations    bool                `json:"has_variations"`
+	ShouldAutoRenew  bool                `json:"should_auto_renew"`
+	Language         string              `json:"language"`
+	// Deprecated: Etsy v3 does not send sku; use SKUs.
+	SKU               []string    `json:"sku"`
+	ShippingProfileID int64       `json:"shipping_profile_id"`
+	ListingType       ListingType `json:"listing_type"`
+
+	// v3 fields
+	CreationTimestamp           timestamp.Timestamp      `json:"creation_timestamp"`
+	CreatedTimestamp            timestamp.Timestamp      `json:"created_timestamp"`
+	EndingTimestamp             timestamp.Timestamp      `json:"ending_timestamp"`
+	OriginalCreationTimestamp   timestamp.Timestamp      `json:"original_creation_timestamp"`
+	LastModifiedTimestamp       timestamp.Timestamp      `json:"last_modified_timestamp"`
+	UpdatedTimestamp            timestamp.Timestamp      `json:"updated_timestamp"`
+	StateTimestamp              timestamp.Timestamp      `json:"state_timestamp"`
+	TaxonomyID                  int64                    `json:"taxonomy_id"`
+	ReturnPolicyID              int64                    `json:"return_policy_id"`
+	ProcessingMin               int                      `json:"processing_min"`
+	ProcessingMax               int                      `json:"processing_max"`
+	ItemWeight                  float64                  `json:"item_weight"`
+	ItemWeightUnit              string                   `json:"item_weight_unit"`
+	ItemLength                  float64                  `json:"item_length"`
+	ItemWidth                   float64                  `json:"item_width"`
+	ItemHeight                  float64                  `json:"item_height"`
+	ItemDimensionsUnit          string                   `json:"item_dimensions_unit"`
+	IsSupply                    bool                     `json:"is_supply"`
+	IsTaxable                   bool                     `json:"is_taxable"`
+	NonTaxable                  bool                     `json:"non_taxable"`
+	PersonalizationIsRequired   bool                     `json:"personalization_is_required"`
+	PersonalizationCharCountMax int                      `json:"personalization_char_count_max"`
+	PersonalizationInstructions string                   `json:"personalization_instructions"`
+	PriceOnProperty             []int64                  `json:"price_on_property"`
+	QuantityOnProperty          []int64                  `json:"quantity_on_property"`
+	SKUOnProperty               []int64                  `json:"sku_on_property"`
+	SKUs                        []string                 `json:"skus"`
+	ProductionPartners          []shop.ProductionPartner `json:"production_partners"`
+
+	// Includes expansions, only present when requested via Includes
+	Images       []ListingImage      `json:"images,omitempty"`
+	Shop         *shop.Shop          `json:"shop,omitempty"`
+	Inventory    *ListingInventory   `json:"inventory,omitempty"`
+	Videos       []ListingVideo      `json:"videos,omitempty"`
+	Translations ListingTranslations `json:"translations,omitempty"`
+
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+func (l *Listing) UnmarshalJSON(data []byte) error {
+	type plain Listing
+	return jsonextra.UnmarshalInto(data, (*plain)(l), &l.Extra)
+}
+
+func (l Listing) MarshalJSON() ([]byte, error) {
+	type plain Listing
+	return jsonextra.MarshalWith(plain(l), l.Extra)
+}
+
+// Amount is kept as an alias of money.Money for backwards compatibility
+type Amount = money.Money
+
+// ListingInventory is the products, offerings and property values of a listing
+type ListingInventory struct {
+	Products           []ListingProduct `json:"products"`
+	PriceOnProperty    []int64          `json:"price_on_property"`
+	QuantityOnProperty []int64          `json:"quantity_on_property"`
+	SKUOnProperty      []int64          `json:"sku_on_property"`
+}
+
+// ListingProduct is one combination of property values of a listing
+type ListingProduct struct {
+	ProductID      int64                  `json:"product_id"`
+	SKU            string                 `json:"sku"`
+	IsDeleted      bool                   `json:"is_deleted"`
+	Offerings      []ListingOffering      `json:"offerings"`
+	PropertyValues []ListingPropertyValue `json:"property_values"`
+
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+func (p *ListingProduct) UnmarshalJSON(data []byte) error {
+	type plain ListingProduct
+	return jsonextra.UnmarshalInto(data, (*plain)(p), &p.Extra)
+}
+
+func (p ListingProduct) MarshalJSON() ([]byte, error) {
+	type plain ListingProduct
+	return jsonextra.MarshalWith(plain(p), p.Extra)
+}
+
+// ListingOffering is the price and quantity of a product
+type ListingOffering struct {
+	OfferingID int64  `json:"offering_id"`
+	Quantity   int    `json:"quantity"`
+	IsEnabled  bool   `json:"is_enabled"`
+	IsDeleted  bool   `json:"is_deleted"`
+	Price      Amount `json:"price"`
+
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+func (o *ListingOffering) UnmarshalJSON(data []byte) error {
+	type plain ListingOffering
+	return jsonextra.UnmarshalInto(data, (*plain)(o), &o.Extra)
+}
+
+func (o ListingOffering) MarshalJSON() ([]byte, error) {
+	type plain ListingOffering
+	return jsonextra.MarshalWith(plain(o), o.Extra)
+}
+
+// ListingPropertyValue is the value of a variation property for a product
+type ListingPropertyValue struct {
+	PropertyID   int64    `json:"property_id"`
+	PropertyName string   `json:"property_name"`
+	ScaleID      int64    `json:"scale_id"`
+	ScaleName    string   `json:"scale_name"`
+	ValueIDs     []int64  `json:"value_ids"`
+	Values       []string `json:"values"`
+}
+
+// ListingVideo is a video attached to a listing
+type ListingVideo struct {
+	VideoID      int64  `json:"video_id"`
+	Height       int    `json:"height"`
+	Width        int    `json:"width"`
+	ThumbnailURL string `json:"thumbnail_url"`
+	VideoURL     string `json:"video_url"`
+	VideoState   string `json:"video_state"`
+
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+func (v *ListingVideo) UnmarshalJSON(data []byte) error {
+	type plain ListingVideo
+	return jsonextra.UnmarshalInto(data, (*plain)(v), &v.Extra)
+}
+
+func (v ListingVideo) MarshalJSON() ([]byte, error) {
+	type plain ListingVideo
+	return jsonextra.MarshalWith(plain(v), v.Extra)
+}
+
+// ListingTranslations holds the translations included with a listing, keyed by language
+type ListingTranslations map[string]ListingTranslation
+
+// UnmarshalJSON accepts both the object keyed by language and a plain array of translations
+func (t *ListingTranslations) UnmarshalJSON(data []byte) error {
+	var byLanguage map[string]ListingTranslation
+	if err := json.Unmarshal(data, &byLanguage); err == nil {
+		*t = byLanguage
+		return nil
+	}
+
+	var list []ListingTranslation
+	if err := json.Unmarshal(data, &list); err != nil {
+		return err
+	}
+	*t = make(ListingTranslations, len(list))
+	for _, tr := range list {
+		(*t)[tr.Language] = tr
+	}
+	return nil
 }
 
 type ListingsResponse struct {
@@ -52,111 +221,131 @@ type ListingsResponse struct {
 // --- Request Bodies ---
 
 type CreateDraftListingRequest struct {
-	Quantity                      int      `url:"quantity"`
-	Title                         string   `url:"title"`
-	Description                   string   `url:"description"`
-	Price                         float64  `url:"price"`
-	WhoMade                       string   `url:"who_made"` // i_did, someone_else, collective
-	WhenMade                      string   `url:"when_made"`
-	TaxonomyID                    int64    `url:"taxonomy_id"`
-	ShippingProfileID             int64    `url:"shipping_profile_id,omitempty"`
-	ReturnPolicyID                int64    `url:"return_policy_id,omitempty"`
-	Materials                     []string `url:"materials,omitempty"`
-	ShopSectionID                 int64    `url:"shop_section_id,omitempty"`
-	ProcessingMin                 int      `url:"processing_min,omitempty"`
-	ProcessingMax                 int      `url:"processing_max,omitempty"`
-	Tags                          []string `url:"tags,omitempty"`
-	Styles                        []string `url:"styles,omitempty"`
-	ItemWeight                    float64  `url:"item_weight,omitempty"`
-	ItemLength                    float64  `url:"item_length,omitempty"`
-	ItemWidth                     float64  `url:"item_width,omitempty"`
-	ItemHeight                    float64  `url:"item_height,omitempty"`
-	ItemWeightUnit                string   `url:"item_weight_unit,omitempty"`
-	ItemDimensionsUnit            string   `url:"item_dimensions_unit,omitempty"`
-	IsPersonalizable              bool     `url:"is_personalizable,omitempty"`
-	PersonalizationIsRequired     bool     `url:"personalization_is_required,omitempty"`
-	PersonalizationCharCountLimit int      `url:"personalization_char_count_limit,omitempty"`
-	PersonalizationInstructions   string   `url:"personalization_instructions,omitempty"`
-	ProductionPartnerIDs          []int64  `url:"production_partner_ids,omitempty"`
-	ImageIDs                      []int64  `url:"image_ids,omitempty"`
-	IsSupply                      bool     `url:"is_supply,omitempty"`
-	IsCustomizable                bool     `url:"is_customizable,omitempty"`
-	ShouldAutoRenew               bool     `url:"should_auto_renew,omitempty"`
-	IsTaxable                     bool     `url:"is_taxable,omitempty"`
-	Type                          string   `url:"type,omitempty"` // physical, download
+	Quantity                      int         `url:"quantity"`
+	Title                         string      `url:"title"`
+	Description                   string      `url:"description"`
+	Price                         money.Money `url:"price"`
+	WhoMade                       WhoMade     `url:"who_made"`
+	WhenMade                      WhenMade    `url:"when_made"`
+	TaxonomyID                    int64       `url:"taxonomy_id"`
+	ShippingProfileID             int64       `url:"shipping_profile_id,omitempty"`
+	ReturnPolicyID                int64       `url:"return_policy_id,omitempty"`
+	Materials                     []string    `url:"materials,omitempty,comma"`
+	ShopSectionID                 int64       `url:"shop_section_id,omitempty"`
+	ProcessingMin                 int         `url:"processing_min,omitempty"`
+	ProcessingMax                 int         `url:"processing_max,omitempty"`
+	Tags                          []string    `url:"tags,omitempty,comma"`
+	Styles                        []string    `url:"styles,omitempty,comma"`
+	ItemWeight                    float64     `url:"item_weight,omitempty"`
+	ItemLength                    float64     `url:"item_length,omitempty"`
+	ItemWidth                     float64     `url:"item_width,omitempty"`
+	ItemHeight                    float64     `url:"item_height,omitempty"`
+	ItemWeightUnit                string      `url:"item_weight_unit,omitempty"`
+	ItemDimensionsUnit            string      `url:"item_dimensions_unit,omitempty"`
+	IsPersonalizable              bool        `url:"is_personalizable,omitempty"`
+	PersonalizationIsRequired     bool        `url:"personalization_is_required,omitempty"`
+	PersonalizationCharCountLimit int         `url:"personalization_char_count_limit,omitempty"`
+	PersonalizationInstructions   string      `url:"personalization_instructions,omitempty"`
+	ProductionPartnerIDs          []int64     `url:"production_partner_ids,omitempty"`
+	ImageIDs                      []int64     `url:"image_ids,omitempty"`
+	IsSupply                      bool        `url:"is_supply,omitempty"`
+	IsCustomizable                bool        `url:"is_customizable,omitempty"`
+	ShouldAutoRenew               bool        `url:"should_auto_renew,omitempty"`
+	IsTaxable                     bool        `url:"is_taxable,omitempty"`
+	Type                          ListingType `url:"type,omitempty"`
 }
 
+// UpdateListingRequest is a partial update: nil pointer fields are left unchanged,
+// so false, zero and empty values can be sent explicitly (e.g. ShouldAutoRenew: Ptr(false)).
+// Tags, Materials and Styles are sent comma-separated; Ptr([]string{}) clears them.
+// WhoMade, WhenMade and State are not pointers: Etsy accepts no empty enum value, so there is nothing to clear.
 type UpdateListingRequest struct {
-	Title                         string   `url:"title,omitempty"`
-	Description                   string   `url:"description,omitempty"`
-	Materials                     []string `url:"materials,omitempty"`
-	ShouldAutoRenew               bool     `url:"should_auto_renew,omitempty"`
-	ShippingProfileID             int64    `url:"shipping_profile_id,omitempty"`
-	ReturnPolicyID                int64    `url:"return_policy_id,omitempty"`
-	ShopSectionID                 int64    `url:"shop_section_id,omitempty"`
-	ItemWeight                    float64  `url:"item_weight,omitempty"`
-	ItemLength                    float64  `url:"item_length,omitempty"`
-	ItemWidth                     float64  `url:"item_width,omitempty"`
-	ItemHeight                    float64  `url:"item_height,omitempty"`
-	ItemWeightUnit                string   `url:"item_weight_unit,omitempty"`
-	ItemDimensionsUnit            string   `url:"item_dimensions_unit,omitempty"`
-	Tags                          []string `url:"tags,omitempty"`
-	WhoMade                       string   `url:"who_made,omitempty"`
-	WhenMade                      string   `url:"when_made,omitempty"`
-	TaxonomyID                    int64    `url:"taxonomy_id,omitempty"`
-	Styles                        []string `url:"styles,omitempty"`
-	ProcessingMin                 int      `url:"processing_min,omitempty"`
-	ProcessingMax                 int      `url:"processing_max,omitempty"`
-	State                         string   `url:"state,omitempty"` // active, inactive, draft
-	FeaturedRank                  int      `url:"featured_rank,omitempty"`
-	IsPersonalizable              bool     `url:"is_personalizable,omitempty"`
-	PersonalizationIsRequired     bool     `url:"personalization_is_required,omitempty"`
-	PersonalizationCharCountLimit int      `url:"personalization_char_count_limit,omitempty"`
-	PersonalizationInstructions   string   `url:"personalization_instructions,omitempty"`
-	IsSupply                      bool     `url:"is_supply,omitempty"`
-	IsCustomizable                bool     `url:"is_customizable,omitempty"`
-	IsTaxable                     bool     `url:"is_taxable,omitempty"`
+	Title                         *string   `url:"title,omitempty"`
+	Description                   *string   `url:"description,omitempty"`
+	Materials                     *[]string `url:"materials,omitempty,comma"`
+	ShouldAutoRenew               *bool     `url:"should_auto_renew,omitempty"`
+	ShippingProfileID             *int64    `url:"shipping_profile_id,omitempty"`
+	ReturnPolicyID                *int64    `url:"return_policy_id,omitempty"`
+	ShopSectionID                 *int64    `url:"shop_section_id,omitempty"`
+	ItemWeight                    *float64  `url:"item_weight,omitempty"`
+	ItemLength                    *float64  `url:"item_length,omitempty"`
+	ItemWidth                     *float64  `url:"item_width,omitempty"`
+	ItemHeight                    *float64  `url:"item_height,omitempty"`
+	ItemWeightUnit                *string   `url:"item_weight_unit,omitempty"`
+	ItemDimensionsUnit            *string   `url:"item_dimensions_unit,omitempty"`
+	Tags                          *[]string `url:"tags,omitempty,comma"`
+	WhoMade                       WhoMade   `url:"who_made,omitempty"`
+	WhenMade                      WhenMade  `url:"when_made,omitempty"`
+	TaxonomyID                    *int64    `url:"taxonomy_id,omitempty"`
+	Styles                        *[]string `url:"styles,omitempty,comma"`
+	ProcessingMin                 *int      `url:"processing_min,omitempty"`
+	ProcessingMax                 *int      `url:"processing_max,omitempty"`
+	State                         State     `url:"state,omitempty"`
+	FeaturedRank                  *int      `url:"featured_rank,omitempty"`
+	IsPersonalizable              *bool     `url:"is_personalizable,omitempty"`
+	PersonalizationIsRequired     *bool     `url:"personalization_is_required,omitempty"`
+	PersonalizationCharCountLimit *int      `url:"personalization_char_count_limit,omitempty"`
+	PersonalizationInstructions   *string   `url:"personalization_instructions,omitempty"`
+	IsSupply                      *bool     `url:"is_supply,omitempty"`
+	IsCustomizable                *bool     `url:"is_customizable,omitempty"`
+	IsTaxable                     *bool     `url:"is_taxable,omitempty"`
+}
+
+// Ptr returns a pointer to v, for setting optional fields of UpdateListingRequest
+func Ptr[T any](v T) *T {
+	return &v
 }
 
 // --- Query Parameters ---
 
+// Values accepted in the Includes parameter of listing requests
+const (
+	IncludeShipping     = "Shipping"
+	IncludeImages       = "Images"
+	IncludeShop         = "Shop"
+	IncludeUser         = "User"
+	IncludeTranslations = "Translations"
+	IncludeInventory    = "Inventory"
+	IncludeVideos       = "Videos"
+)
+
 type GetListingParams struct {
 	Includes []string `url:"includes,omitempty,comma"`
 	Language string   `url:"language,omitempty"`
 }
 
 type GetListingsByShopParams struct {
-	State     string   `url:"state,omitempty"` // active, inactive, draft, expired, sold_out
-	Limit     int      `url:"limit,omitempty"`
-	Offset    int      `url:"offset,omitempty"`
-	SortOn    string   `url:"sort_on,omitempty"`    // created, price, updated, score
-	SortOrder string   `url:"sort_order,omitempty"` // asc, desc
-	Includes  []string `url:"includes,omitempty,comma"`
-	Keywords  string   `url:"keywords,omitempty"`
-	Language  string   `url:"language,omitempty"`
+	State     State     `url:"state,omitempty"`
+	Limit     int       `url:"limit,omitempty"`
+	Offset    int       `url:"offset,omitempty"`
+	SortOn    SortOn    `url:"sort_on,omitempty"`
+	SortOrder SortOrder `url:"sort_order,omitempty"`
+	Includes  []string  `url:"includes,omitempty,comma"`
+	Keywords  string    `url:"keywords,omitempty"`
+	Language  string    `url:"language,omitempty"`
 }
 
 type FindAllActiveListingsByShopParams struct {
-	Limit     int      `url:"limit,omitempty"`
-	Offset    int      `url:"offset,omitempty"`
-	Keywords  string   `url:"keywords,omitempty"`
-	SortOn    string   `url:"sort_on,omitempty"`
-	SortOrder string   `url:"sort_order,omitempty"`
-	Includes  []string `url:"includes,omitempty,comma"`
-	Language  string   `url:"language,omitempty"`
+	Limit     int       `url:"limit,omitempty"`
+	Offset    int       `url:"offset,omitempty"`
+	Keywords  string    `url:"keywords,omitempty"`
+	SortOn    SortOn    `url:"sort_on,omitempty"`
+	SortOrder SortOrder `url:"sort_order,omitempty"`
+	Includes  []string  `url:"includes,omitempty,comma"`
+	Language  string    `url:"language,omitempty"`
 }
 
 type FindAllListingsActiveParams struct {
-	Limit        int      `url:"limit,omitempty"`
-	Offset       int      `url:"offset,omitempty"`
-	Keywords     string   `url:"keywords,omitempty"`
-	SortOn       string   `url:"sort_on,omitempty"`
-	SortOrder    string   `url:"sort_order,omitempty"`
-	MinPrice     float64  `url:"min_price,omitempty"`
-	MaxPrice     float64  `url:"max_price,omitempty"`
-	TaxonomyID   int64    `url:"taxonomy_id,omitempty"`
-	ShopLocation string   `url:"shop_location,omitempty"`
-	Includes     []string `url:"includes,omitempty,comma"`
+	Limit        int       `url:"limit,omitempty"`
+	Offset       int       `url:"offset,omitempty"`
+	Keywords     string    `url:"keywords,omitempty"`
+	SortOn       SortOn    `url:"sort_on,omitempty"`
+	SortOrder    SortOrder `url:"sort_order,omitempty"`
+	MinPrice     float64   `url:"min_price,omitempty"`
+	MaxPrice     float64   `url:"max_price,omitempty"`
+	TaxonomyID   int64     `url:"taxonomy_id,omitempty"`
+	ShopLocation string    `url:"shop_location,omitempty"`
+	Includes     []string  `url:"includes,omitempty,comma"`
 }
 
 type GetListingsByListingIdsParams struct {
@@ -165,11 +354,11 @@ type GetListingsByListingIdsParams struct {
 }
 
 type GetListingsByShopSectionIdParams struct {
-	Limit     int      `url:"limit,omitempty"`
-	Offset    int      `url:"offset,omitempty"`
-	SortOn    string   `url:"sort_on,omitempty"`
-	SortOrder string   `url:"sort_order,omitempty"`
-	Includes  []string `url:"includes,omitempty,comma"`
+	Limit     int       `url:"limit,omitempty"`
+	Offset    int       `url:"offset,omitempty"`
+	SortOn    SortOn    `url:"sort_on,omitempty"`
+	SortOrder SortOrder `url:"sort_order,omitempty"`
+	Includes  []string  `url:"includes,omitempty,comma"`
 }
 
 type GetListingsByShopReceiptParams struct {
@@ -180,25 +369,37 @@ type GetListingsByShopReceiptParams struct {
 
 // ListingImage represents an image associated with a listing
 type ListingImage struct {
-	ListingID       int64  `json:"listing_id"`
-	ListingImageID  int64  `json:"listing_image_id"`
-	HexCode         string `json:"hex_code"`
-	Red             int    `json:"red"`
-	Green           int    `json:"green"`
-	Blue            int    `json:"blue"`
-	Hue             int    `json:"hue"`
-	Saturation      int    `json:"saturation"`
-	Brightness      int    `json:"brightness"`
-	IsBlackAndWhite bool   `json:"is_black_and_white"`
-	CreationTsz     int64  `json:"creation_tsz"`
-	Rank            int    `json:"rank"`
-	Url75x75        string `json:"url_75x75"`
-	Url170x135      string `json:"url_170x135"`
-	Url570xN        string `json:"url_570xN"`
-	UrlFullxFull    string `json:"url_fullxfull"`
-	FullHeight      int    `json:"full_height"`
-	FullWidth       int    `json:"full_width"`
-	AltText         string `json:"alt_text"`
+	ListingID       int64               `json:"listing_id"`
+	ListingImageID  int64               `json:"listing_image_id"`
+	HexCode         string              `json:"hex_code"`
+	Red             int                 `json:"red"`
+	Green           int                 `json:"green"`
+	Blue            int                 `json:"blue"`
+	Hue             int                 `json:"hue"`
+	Saturation      int                 `json:"saturation"`
+	Brightness      int                 `json:"brightness"`
+	IsBlackAndWhite bool                `json:"is_black_and_white"`
+	CreationTsz     timestamp.Timestamp `json:"creation_tsz"`
+	Rank            int                 `json:"rank"`
+	Url75x75        string              `json:"url_75x75"`
+	Url170x135      string              `json:"url_170x135"`
+	Url570xN        string              `json:"url_570xN"`
+	UrlFullxFull    string              `json:"url_fullxfull"`
+	FullHeight      int                 `json:"full_height"`
+	FullWidth       int                 `json:"full_width"`
+	AltText         string              `json:"alt_text"`
+
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+func (i *ListingImage) UnmarshalJSON(data []byte) error {
+	type plain ListingImage
+	return jsonextra.UnmarshalInto(data, (*plain)(i), &i.Extra)
+}
+
+func (i ListingImage) MarshalJSON() ([]byte, error) {
+	type plain ListingImage
+	return jsonextra.MarshalWith(plain(i), i.Extra)
 }
 
 // ListingImagesResponse is the response body for GetListingImages
@@ -206,3 +407,85 @@ type ListingImagesResponse struct {
 	Count   int            `json:"count"`
 	Results []ListingImage `json:"results"`
 }
+
+// ListingTranslation is the title, description and tags of a listing in a given language
+type ListingTranslation struct {
+	ListingID   int64    `json:"listing_id"`
+	Language    string   `json:"language"`
+	Title       string   `json:"title"`
+	Description string   `json:"description"`
+	Tags        []string `json:"tags"`
+
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+func (t *ListingTranslation) UnmarshalJSON(data []byte) error {
+	type plain ListingTranslation
+	return jsonextra.UnmarshalInto(data, (*plain)(t), &t.Extra)
+}
+
+func (t ListingTranslation) MarshalJSON() ([]byte, error) {
+	type plain ListingTranslation
+	return jsonextra.MarshalWith(plain(t), t.Extra)
+}
+
+// ListingTranslationRequest is the body for CreateListingTranslation and UpdateListingTranslation
+type ListingTranslationRequest struct {
+	Title       string   `url:"title"`
+	Description string   `url:"description"`
+	Tags        []string `url:"tags,omitempty,comma"`
+}
+
+// VariationImage binds a listing image to a variation property value
+type VariationImage struct {
+	PropertyID int64  `json:"property_id"`
+	ValueID    int64  `json:"value_id"`
+	Value      string `json:"value,omitempty"`
+	ImageID    int64  `json:"image_id"`
+}
+
+// VariationImagesResponse is the response body for GetListingVariationImages and UpdateVariationImages
+type VariationImagesResponse struct {
+	Count   int              `json:"count"`
+	Results []VariationImage `json:"results"`
+}
+
+// UpdateVariationImagesRequest is the JSON body for UpdateVariationImages.
+// Value is ignored by Etsy on write.
+type UpdateVariationImagesRequest struct {
+	VariationImages []VariationImage `json:"variation_images"`
+}
+
+// PersonalizationQuestionType is the kind of input a buyer provides for a personalization question
+type PersonalizationQuestionType string
+
+const (
+	PersonalizationQuestionTextInput  PersonalizationQuestionType = "text_input"
+	PersonalizationQuestionDropdown   PersonalizationQuestionType = "dropdown"
+	PersonalizationQuestionFileUpload PersonalizationQuestionType = "unlabeled_file_upload"
+)
+
+// PersonalizationOption is a selectable answer of a dropdown question
+type PersonalizationOption struct {
+	OptionID int64  `json:"option_id,omitempty"`
+	Label    string `json:"label"`
+}
+
+// PersonalizationQuestion is a single question asked to the buyer at checkout.
+// MaxAllowedCharacters applies to text inputs, Options to dropdowns and MaxAllowedFiles to file uploads.
+type PersonalizationQuestion struct {
+	QuestionID           int64                       `json:"question_id,omitempty"`
+	QuestionType         PersonalizationQuestionType `json:"question_type"`
+	QuestionText         string                      `json:"question_text"`
+	Instructions         string                      `json:"instructions,omitempty"`
+	Required             bool                        `json:"required"`
+	MaxAllowedCharacters int                         `json:"max_allowed_characters,omitempty"`
+	MaxAllowedFiles      int                         `json:"max_allowed_files,omitempty"`
+	Options              []PersonalizationOption     `json:"options,omitempty"`
+}
+
+// PersonalizationProfile is the set of personalization questions attached to a listing.
+// It replaces the legacy IsPersonalizable / PersonalizationInstructions / PersonalizationCharCountLimit fields.
+type PersonalizationProfile struct {
+	PersonalizationQuestions []PersonalizationQuestion `json:"personalization_questions"`
+}
@@ -0,0 +1,238 @@
+package listing
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/google/go-querystring/query"
+)
+
+func TestUpdateListingRequestEncoding(t *testing.T) {
+	tests := []struct {
+		field string
+		req   UpdateListingRequest
+		key   string
+		want  string
+	}{
+		{"Title", UpdateListingRequest{Title: Ptr("")}, "title", ""},
+		{"Description", UpdateListingRequest{Description: Ptr("")}, "description", ""},
+		{"Materials", UpdateListingRequest{Materials: Ptr([]string{})}, "materials", ""},
+		{"ShouldAutoRenew", UpdateListingRequest{ShouldAutoRenew: Ptr(false)}, "should_auto_renew", "false"},
+		{"ShippingProfileID", UpdateListingRequest{ShippingProfileID: Ptr[int64](0)}, "shipping_profile_id", "0"},
+		{"ReturnPolicyID", UpdateListingRequest{ReturnPolicyID: Ptr[int64](0)}, "return_policy_id", "0"},
+		{"ShopSectionID", UpdateListingRequest{ShopSectionID: Ptr[int64](0)}, "shop_section_id", "0"},
+		{"ItemWeight", UpdateListingRequest{ItemWeight: Ptr(0.0)}, "item_weight", "0"},
+		{"ItemLength", UpdateListingRequest{ItemLength: Ptr(0.0)}, "item_length", "0"},
+		{"ItemWidth", UpdateListingRequest{ItemWidth: Ptr(0.0)}, "item_width", "0"},
+		{"ItemHeight", UpdateListingRequest{ItemHeight: Ptr(0.0)}, "item_height", "0"},
+		{"ItemWeightUnit", UpdateListingRequest{ItemWeightUnit: Ptr("")}, "item_weight_unit", ""},
+		{"ItemDimensionsUnit", UpdateListingRequest{ItemDimensionsUnit: Ptr("")}, "item_dimensions_unit", ""},
+		{"Tags", UpdateListingRequest{Tags: Ptr([]string{})}, "tags", ""},
+		{"TaxonomyID", UpdateListingRequest{TaxonomyID: Ptr[int64](0)}, "taxonomy_id", "0"},
+		{"Styles", UpdateListingRequest{Styles: Ptr([]string{})}, "styles", ""},
+		{"ProcessingMin", UpdateListingRequest{ProcessingMin: Ptr(0)}, "processing_min", "0"},
+		{"ProcessingMax", UpdateListingRequest{ProcessingMax: Ptr(0)}, "processing_max", "0"},
+		{"FeaturedRank", UpdateListingRequest{FeaturedRank: Ptr(0)}, "featured_rank", "0"},
+		{"IsPersonalizable", UpdateListingRequest{IsPersonalizable: Ptr(false)}, "is_personalizable", "false"},
+		{"PersonalizationIsRequired", UpdateListingRequest{PersonalizationIsRequired: Ptr(false)}, "personalization_is_required", "false"},
+		{"PersonalizationCharCountLimit", UpdateListingRequest{PersonalizationCharCountLimit: Ptr(0)}, "personalization_char_count_limit", "0"},
+		{"PersonalizationInstructions", UpdateListingRequest{PersonalizationInstructions: Ptr("")}, "personalization_instructions", ""},
+		{"IsSupply", UpdateListingRequest{IsSupply: Ptr(false)}, "is_supply", "false"},
+		{"IsCustomizable", UpdateListingRequest{IsCustomizable: Ptr(false)}, "is_customizable", "false"},
+		{"IsTaxable", UpdateListingRequest{IsTaxable: Ptr(false)}, "is_taxable", "false"},
+	}
+
+	covered := map[string]bool{}
+	for _, tt := range tests {
+		t.Run(tt.field, func(t *testing.T) {
+			covered[tt.field] = true
+
+			v, err := query.Values(tt.req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, ok := v[tt.key]
+			if !ok || len(got) != 1 || got[0] != tt.want {
+				t.Errorf("%s = %q, want [%q]", tt.key, got, tt.want)
+			}
+			if len(v) != 1 {
+				t.Errorf("only %s should be sent, got %v", tt.key, v)
+			}
+
+			empty, err := query.Values(UpdateListingRequest{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, ok := empty[tt.key]; ok {
+				t.Errorf("%s sent although the field is nil", tt.key)
+			}
+		})
+	}
+
+	typ := reflect.TypeOf(UpdateListingRequest{})
+	for i := 0; i < typ.NumField(); i++ {
+		if f := typ.Field(i); f.Type.Kind() == reflect.Pointer && !covered[f.Name] {
+			t.Errorf("pointer field %s has no encoding test", f.Name)
+		}
+	}
+}
+
+// Etsy reads string lists in form bodies as one comma-separated value; repeated keys keep only one item.
+func TestListEncoding(t *testing.T) {
+	tests := []struct {
+		name string
+		body interface{}
+		key  string
+		want string
+	}{
+		{"update tags", UpdateListingRequest{Tags: Ptr([]string{"wool", "hand knit"})}, "tags", "wool,hand knit"},
+		{"create tags", CreateDraftListingRequest{Tags: []string{"wool", "hand knit"}}, "tags", "wool,hand knit"},
+		{"create materials", CreateDraftListingRequest{Materials: []string{"wool", "cotton"}}, "materials", "wool,cotton"},
+		{"create styles", CreateDraftListingRequest{Styles: []string{"boho", "retro"}}, "styles", "boho,retro"},
+		{"translation tags", ListingTranslationRequest{Tags: []string{"laine", "tricot"}}, "tags", "laine,tricot"},
+	}
+	for _, tt := range tests {
+		v, err := query.Values(tt.body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := v[tt.key]; len(got) != 1 || got[0] != tt.want {
+			t.Errorf("%s: %s = %q, want [%q]", tt.name, tt.key, got, tt.want)
+		}
+	}
+
+	v, err := query.Values(ListingTranslationRequest{Title: "t"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := v["tags"]; ok {
+		t.Errorf("nil translation tags sent: %v", v)
+	}
+}
+
+func TestUpdateListingRequestValidateRejectsEmptyTitle(t *testing.T) {
+	err := UpdateListingRequest{Title: Ptr(" "), Description: Ptr("")}.Validate()
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	for _, field := range []string{"title", "description"} {
+		if !strings.Contains(err.Error(), field+": must not be empty") {
+			t.Errorf("missing %s error in %q", field, err)
+		}
+	}
+}
+
+// listingV3 is a getListing response with every include, trimmed to the fields the test checks
+// plus a few the model does not declare.
+const listingV3 = `{
+  "listing_id": 1234567890,
+  "user_id": 111,
+  "shop_id": 222,
+  "title": "Hand knit scarf",
+  "state": "active",
+  "creation_timestamp": 1700000000,
+  "created_timestamp": 1700000000,
+  "ending_timestamp": 1710000000,
+  "last_modified_timestamp": 1700086400,
+  "updated_timestamp": 1700086400,
+  "quantity": 4,
+  "skus": ["SCARF-RED"],
+  "who_made": "i_did",
+  "when_made": "made_to_order",
+  "listing_type": "physical",
+  "price": {"amount": 2500, "divisor": 100, "currency_code": "USD"},
+  "production_partners": [{"production_partner_id": 9, "partner_name": "Knit Co", "location": "Lisbon"}],
+  "is_made_to_order": true,
+  "images": [{
+    "listing_id": 1234567890, "listing_image_id": 55, "rank": 1, "creation_tsz": 1700000000,
+    "url_570xN": "https://i.etsystatic.com/570xN.jpg", "alt_text": "Red scarf", "created_timestamp": 1700000000
+  }],
+  "shop": {"shop_id": 222, "shop_name": "KnitShop", "user_id": 111, "created_timestamp": 1600000000},
+  "inventory": {
+    "products": [{
+      "product_id": 77, "sku": "SCARF-RED", "is_deleted": false,
+      "offerings": [{"offering_id": 88, "quantity": 4, "is_enabled": true, "is_deleted": false,
+        "price": {"amount": 2500, "divisor": 100, "currency_code": "USD"}}],
+      "property_values": [{"property_id": 200, "property_name": "Primary color", "scale_id": null,
+        "scale_name": null, "value_ids": [1], "values": ["Red"]}]
+    }],
+    "price_on_property": [], "quantity_on_property": [], "sku_on_property": [200]
+  },
+  "videos": [{"video_id": 66, "height": 720, "width": 1280, "thumbnail_url": "https://v/t.jpg",
+    "video_url": "https://v/v.mp4", "video_state": "active"}],
+  "translations": [
+    {"listing_id": 1234567890, "language": "de", "title": "Handgestrickter Schal", "description": "Warm", "tags": ["wolle"]},
+    {"listing_id": 1234567890, "language": "fr", "title": "Écharpe tricotée", "description": "Chaude", "tags": []}
+  ]
+}`
+
+func TestListingDecodesV3Payload(t *testing.T) {
+	var l Listing
+	if err := json.Unmarshal([]byte(listingV3), &l); err != nil {
+		t.Fatal(err)
+	}
+
+	if l.ListingID != 1234567890 || l.State != StateActive || l.Quantity != 4 || l.ListingType != ListingTypePhysical {
+		t.Errorf("core fields: %+v", l)
+	}
+	if l.CreationTimestamp.Unix() != 1700000000 || l.LastModifiedTimestamp.Unix() != 1700086400 || l.EndingTimestamp.Unix() != 1710000000 {
+		t.Errorf("timestamps: %v %v %v", l.CreationTimestamp, l.LastModifiedTimestamp, l.EndingTimestamp)
+	}
+	if !l.CreationTsz.IsZero() || l.SKU != nil {
+		t.Errorf("deprecated fields set from a v3 payload: %v %v", l.CreationTsz, l.SKU)
+	}
+	if len(l.SKUs) != 1 || l.SKUs[0] != "SCARF-RED" || l.Price.Format() != "25.00" {
+		t.Errorf("skus %v, price %v", l.SKUs, l.Price)
+	}
+	if len(l.ProductionPartners) != 1 || l.ProductionPartners[0].PartnerName != "Knit Co" {
+		t.Errorf("production partners: %+v", l.ProductionPartners)
+	}
+	if string(l.Extra["is_made_to_order"]) != "true" {
+		t.Errorf("undeclared field not kept: %v", l.Extra)
+	}
+
+	if len(l.Images) != 1 || l.Images[0].ListingImageID != 55 || l.Images[0].AltText != "Red scarf" ||
+		l.Images[0].CreationTsz.Unix() != 1700000000 || l.Images[0].Extra["created_timestamp"] == nil {
+		t.Errorf("images: %+v", l.Images)
+	}
+	if l.Shop == nil || l.Shop.ShopName != "KnitShop" || l.Shop.CreatedTimestamp.Unix() != 1600000000 {
+		t.Errorf("shop: %+v", l.Shop)
+	}
+	if l.Inventory == nil || len(l.Inventory.Products) != 1 {
+		t.Fatalf("inventory: %+v", l.Inventory)
+	}
+	p := l.Inventory.Products[0]
+	if p.SKU != "SCARF-RED" || len(p.Offerings) != 1 || p.Offerings[0].Price.Amount != 2500 ||
+		len(p.PropertyValues) != 1 || p.PropertyValues[0].Values[0] != "Red" || l.Inventory.SKUOnProperty[0] != 200 {
+		t.Errorf("inventory product: %+v", p)
+	}
+	if len(l.Videos) != 1 || l.Videos[0].VideoURL != "https://v/v.mp4" {
+		t.Errorf("videos: %+v", l.Videos)
+	}
+	if len(l.Translations) != 2 || l.Translations["de"].Title != "Handgestrickter Schal" || l.Translations["fr"].Description != "Chaude" {
+		t.Errorf("translations: %+v", l.Translations)
+	}
+}
+
+func TestListingTranslationsDecodesBothFormats(t *testing.T) {
+	for _, in := range []string{
+		`[{"language":"de","title":"Schal"}]`,
+		`{"de":{"language":"de","title":"Schal"}}`,
+	} {
+		var tr ListingTranslations
+		if err := json.Unmarshal([]byte(in), &tr); err != nil {
+			t.Errorf("%s: %v", in, err)
+			continue
+		}
+		if len(tr) != 1 || tr["de"].Title != "Schal" {
+			t.Errorf("%s: got %+v", in, tr)
+		}
+	}
+
+	var tr ListingTranslations
+	if err := json.Unmarshal([]byte(`"de"`), &tr); err == nil {
+		t.Error("expected an error for a string")
+	}
+}
@@ -0,0 +1,218 @@
+package listing
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// Limits enforced by Etsy on listing fields
+const (
+	MaxTitleLength                   = 140
+	MaxTags                          = 13
+	MaxTagLength                     = 20
+	MaxMaterials                     = 13
+	MaxMaterialLength                = 45
+	MaxStyles                        = 2
+	MaxStyleLength                   = 45
+	MaxPersonalizationCharCount      = 1024
+	MaxPersonalizationInstructionLen = 256
+)
+
+// FieldError describes a single invalid field of a request
+type FieldError struct {
+	Field   string
+	Message string
+}
+
+func (e FieldError) Error() string {
+	return e.Field + ": " + e.Message
+}
+
+// ValidationErrors is returned by Validate and lists every invalid field of a request
+type ValidationErrors []FieldError
+
+func (e ValidationErrors) Error() string {
+	msgs := make([]string, len(e))
+	for i, fe := range e {
+		msgs[i] = fe.Error()
+	}
+	return "invalid listing request: " + strings.Join(msgs, "; ")
+}
+
+type validator struct {
+	errs ValidationErrors
+}
+
+func (v *validator) add(field, format string, args ...interface{}) {
+	v.errs = append(v.errs, FieldError{Field: field, Message: fmt.Sprintf(format, args...)})
+}
+
+func (v *validator) err() error {
+	if len(v.errs) == 0 {
+		return nil
+	}
+	return v.errs
+}
+
+func (v *validator) maxLen(field, value string, max int) {
+	if n := utf8.RuneCountInString(value); n > max {
+		v.add(field, "must be at most %d characters, got %d", max, n)
+	}
+}
+
+func (v *validator) list(field string, values []string, maxItems, maxItemLen int) {
+	if len(values) > maxItems {
+		v.add(field, "must have at most %d items, got %d", maxItems, len(values))
+	}
+	for i, value := range values {
+		if strings.TrimSpace(value) == "" {
+			v.add(fmt.Sprintf("%s[%d]", field, i), "must not be empty")
+		}
+		v.maxLen(fmt.Sprintf("%s[%d]", field, i), value, maxItemLen)
+	}
+}
+
+// Validate checks the request against Etsy's rules for draft listings
+func (r CreateDraftListingRequest) Validate() error {
+	var v validator
+
+	if strings.TrimSpace(r.Title) == "" {
+		v.add("title", "is required")
+	}
+	v.maxLen("title", r.Title, MaxTitleLength)
+	if strings.TrimSpace(r.Description) == "" {
+		v.add("description", "is required")
+	}
+	if r.Quantity <= 0 {
+		v.add("quantity", "must be greater than 0")
+	}
+	if r.Price.Amount <= 0 {
+		v.add("price", "must be greater than 0")
+	}
+	if r.WhoMade == "" {
+		v.add("who_made", "is required")
+	} else if !r.WhoMade.Valid() {
+		v.add("who_made", "invalid value %q", r.WhoMade)
+	}
+	if r.WhenMade == "" {
+		v.add("when_made", "is required")
+	} else if !r.WhenMade.Valid() {
+		v.add("when_made", "invalid value %q", r.WhenMade)
+	}
+	if r.TaxonomyID <= 0 {
+		v.add("taxonomy_id", "is required")
+	}
+	if r.Type != "" && !r.Type.Valid() {
+		v.add("type", "invalid value %q", r.Type)
+	}
+
+	v.list("tags", r.Tags, MaxTags, MaxTagLength)
+	v.list("materials", r.Materials, MaxMaterials, MaxMaterialLength)
+	v.list("styles", r.Styles, MaxStyles, MaxStyleLength)
+	v.processing(r.ProcessingMin, r.ProcessingMax)
+	v.dimensions(r.ItemWeight, r.ItemWeightUnit, []float64{r.ItemLength, r.ItemWidth, r.ItemHeight}, r.ItemDimensionsUnit)
+	v.personalization(r.IsPersonalizable, r.PersonalizationIsRequired, r.PersonalizationCharCountLimit, r.PersonalizationInstructions)
+
+	return v.err()
+}
+
+// Validate checks the fields set on the request against Etsy's rules for listings
+func (r UpdateListingRequest) Validate() error {
+	var v validator
+
+	if r.Title != nil {
+		if strings.TrimSpace(*r.Title) == "" {
+			v.add("title", "must not be empty")
+		}
+		v.maxLen("title", *r.Title, MaxTitleLength)
+	}
+	if r.Description != nil && strings.TrimSpace(*r.Description) == "" {
+		v.add("description", "must not be empty")
+	}
+	if r.WhoMade != "" && !r.WhoMade.Valid() {
+		v.add("who_made", "invalid value %q", r.WhoMade)
+	}
+	if r.WhenMade != "" && !r.WhenMade.Valid() {
+		v.add("when_made", "invalid value %q", r.WhenMade)
+	}
+	if r.State != "" && r.State != StateActive && r.State != StateInactive {
+		v.add("state", "can only be set to %s or %s", StateActive, StateInactive)
+	}
+	if r.TaxonomyID != nil && *r.TaxonomyID <= 0 {
+		v.add("taxonomy_id", "must be greater than 0")
+	}
+	if r.FeaturedRank != nil && *r.FeaturedRank < 0 {
+		v.add("featured_rank", "must not be negative")
+	}
+
+	v.list("tags", deref(r.Tags), MaxTags, MaxTagLength)
+	v.list("materials", deref(r.Materials), MaxMaterials, MaxMaterialLength)
+	v.list("styles", deref(r.Styles), MaxStyles, MaxStyleLength)
+	v.processing(deref(r.ProcessingMin), deref(r.ProcessingMax))
+	v.dimensions(deref(r.ItemWeight), deref(r.ItemWeightUnit), []float64{deref(r.ItemLength), deref(r.ItemWidth), deref(r.ItemHeight)}, deref(r.ItemDimensionsUnit))
+
+	// Personalization fields may be updated without resending is_personalizable,
+	// so only reject them when it is explicitly being turned off.
+	if r.IsPersonalizable != nil && !*r.IsPersonalizable &&
+		(deref(r.PersonalizationIsRequired) || deref(r.PersonalizationCharCountLimit) != 0 || deref(r.PersonalizationInstructions) != "") {
+		v.add("is_personalizable", "must be true when personalization fields are set")
+	}
+	if limit := deref(r.PersonalizationCharCountLimit); limit < 0 || limit > MaxPersonalizationCharCount {
+		v.add("personalization_char_count_limit", "must be between 0 and %d", MaxPersonalizationCharCount)
+	}
+	v.maxLen("personalization_instructions", deref(r.PersonalizationInstructions), MaxPersonalizationInstructionLen)
+
+	return v.err()
+}
+
+// deref returns the value p points to, or the zero value if p is nil
+func deref[T any](p *T) T {
+	var zero T
+	if p == nil {
+		return zero
+	}
+	return *p
+}
+
+func (v *validator) processing(min, max int) {
+	if min < 0 {
+		v.add("processing_min", "must not be negative")
+	}
+	if max < 0 {
+		v.add("processing_max", "must not be negative")
+	}
+	if min > 0 && max > 0 && min > max {
+		v.add("processing_min", "must be less than or equal to processing_max (%d > %d)", min, max)
+	}
+}
+
+func (v *validator) dimensions(weight float64, weightUnit string, dims []float64, dimsUnit string) {
+	if weight < 0 {
+		v.add("item_weight", "must not be negative")
+	}
+	if weight > 0 && weightUnit == "" {
+		v.add("item_weight_unit", "is required when item_weight is set")
+	}
+	names := []string{"item_length", "item_width", "item_height"}
+	anyDim := false
+	for i, d := range dims {
+		if d < 0 {
+			v.add(names[i], "must not be negative")
+		}
+		anyDim = anyDim || d > 0
+	}
+	if anyDim && dimsUnit == "" {
+		v.add("item_dimensions_unit", "is required when item dimensions are set")
+	}
+}
+
+func (v *validator) personalization(isPersonalizable, isRequired bool, charCountLimit int, instructions string) {
+	if !isPersonalizable && (isRequired || charCountLimit != 0 || instructions != "") {
+		v.add("is_personalizable", "must be true when personalization fields are set")
+	}
+	if charCountLimit < 0 || charCountLimit > MaxPersonalizationCharCount {
+		v.add("personalization_char_count_limit", "must be between 0 and %d", MaxPersonalizationCharCount)
+	}
+	v.maxLen("personalization_instructions", instructions, MaxPersonalizationInstructionLen)
+}
@@ -0,0 +1,141 @@
+package listing
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/dzt-corp/go-etsy/money"
+)
+
+func validDraft() CreateDraftListingRequest {
+	return CreateDraftListingRequest{
+		Title:       "Hand knit scarf",
+		Description: "Warm wool scarf",
+		Quantity:    1,
+		Price:       money.New(2500, 100, "USD"),
+		WhoMade:     WhoMadeIDid,
+		WhenMade:    WhenMadeToOrder,
+		TaxonomyID:  1,
+	}
+}
+
+func TestCreateDraftListingRequestValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(r *CreateDraftListingRequest)
+		field  string // empty when the request is valid
+	}{
+		{"valid", func(r *CreateDraftListingRequest) {}, ""},
+		{"title required", func(r *CreateDraftListingRequest) { r.Title = " " }, "title"},
+		{"title too long", func(r *CreateDraftListingRequest) { r.Title = strings.Repeat("é", MaxTitleLength+1) }, "title"},
+		{"title at limit", func(r *CreateDraftListingRequest) { r.Title = strings.Repeat("é", MaxTitleLength) }, ""},
+		{"description required", func(r *CreateDraftListingRequest) { r.Description = "" }, "description"},
+		{"quantity", func(r *CreateDraftListingRequest) { r.Quantity = 0 }, "quantity"},
+		{"price", func(r *CreateDraftListingRequest) { r.Price = money.New(0, 100, "USD") }, "price"},
+		{"who_made required", func(r *CreateDraftListingRequest) { r.WhoMade = "" }, "who_made"},
+		{"who_made invalid", func(r *CreateDraftListingRequest) { r.WhoMade = "robot" }, "who_made"},
+		{"when_made required", func(r *CreateDraftListingRequest) { r.WhenMade = "" }, "when_made"},
+		{"when_made invalid", func(r *CreateDraftListingRequest) { r.WhenMade = "2030_2035" }, "when_made"},
+		{"taxonomy required", func(r *CreateDraftListingRequest) { r.TaxonomyID = 0 }, "taxonomy_id"},
+		{"type invalid", func(r *CreateDraftListingRequest) { r.Type = "service" }, "type"},
+		{"too many tags", func(r *CreateDraftListingRequest) { r.Tags = make([]string, MaxTags+1); fill(r.Tags) }, "tags"},
+		{"tag too long", func(r *CreateDraftListingRequest) { r.Tags = []string{strings.Repeat("a", MaxTagLength+1)} }, "tags[0]"},
+		{"empty tag", func(r *CreateDraftListingRequest) { r.Tags = []string{"ok", " "} }, "tags[1]"},
+		{"too many materials", func(r *CreateDraftListingRequest) { r.Materials = make([]string, MaxMaterials+1); fill(r.Materials) }, "materials"},
+		{"material too long", func(r *CreateDraftListingRequest) { r.Materials = []string{strings.Repeat("a", MaxMaterialLength+1)} }, "materials[0]"},
+		{"too many styles", func(r *CreateDraftListingRequest) { r.Styles = []string{"a", "b", "c"} }, "styles"},
+		{"style too long", func(r *CreateDraftListingRequest) { r.Styles = []string{strings.Repeat("a", MaxStyleLength+1)} }, "styles[0]"},
+		{"processing min > max", func(r *CreateDraftListingRequest) { r.ProcessingMin, r.ProcessingMax = 5, 3 }, "processing_min"},
+		{"processing negative", func(r *CreateDraftListingRequest) { r.ProcessingMax = -1 }, "processing_max"},
+		{"processing only min", func(r *CreateDraftListingRequest) { r.ProcessingMin = 5 }, ""},
+		{"weight without unit", func(r *CreateDraftListingRequest) { r.ItemWeight = 1.5 }, "item_weight_unit"},
+		{"weight with unit", func(r *CreateDraftListingRequest) { r.ItemWeight, r.ItemWeightUnit = 1.5, "kg" }, ""},
+		{"negative weight", func(r *CreateDraftListingRequest) { r.ItemWeight, r.ItemWeightUnit = -1, "kg" }, "item_weight"},
+		{"dimensions without unit", func(r *CreateDraftListingRequest) { r.ItemHeight = 10 }, "item_dimensions_unit"},
+		{"negative dimension", func(r *CreateDraftListingRequest) { r.ItemWidth, r.ItemDimensionsUnit = -1, "cm" }, "item_width"},
+		{"personalization off", func(r *CreateDraftListingRequest) { r.PersonalizationInstructions = "Name" }, "is_personalizable"},
+		{"personalization on", func(r *CreateDraftListingRequest) {
+			r.IsPersonalizable, r.PersonalizationIsRequired, r.PersonalizationInstructions = true, true, "Name"
+		}, ""},
+		{"personalization char limit", func(r *CreateDraftListingRequest) {
+			r.IsPersonalizable, r.PersonalizationCharCountLimit = true, MaxPersonalizationCharCount+1
+		}, "personalization_char_count_limit"},
+		{"personalization instructions too long", func(r *CreateDraftListingRequest) {
+			r.IsPersonalizable, r.PersonalizationInstructions = true, strings.Repeat("a", MaxPersonalizationInstructionLen+1)
+		}, "personalization_instructions"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := validDraft()
+			tt.modify(&r)
+			err := r.Validate()
+
+			if tt.field == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			var verrs ValidationErrors
+			if !errors.As(err, &verrs) {
+				t.Fatalf("got %v, want ValidationErrors", err)
+			}
+			if len(verrs) != 1 || verrs[0].Field != tt.field {
+				t.Errorf("got %v, want one error on %s", verrs, tt.field)
+			}
+		})
+	}
+}
+
+func TestValidationErrorsListsEveryField(t *testing.T) {
+	err := CreateDraftListingRequest{}.Validate()
+	for _, field := range []string{"title", "description", "quantity", "price", "who_made", "when_made", "taxonomy_id"} {
+		if !strings.Contains(err.Error(), field+": ") {
+			t.Errorf("%q does not mention %s", err, field)
+		}
+	}
+}
+
+func TestWithValidationBlocksInvalidDraft(t *testing.T) {
+	requests := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Write([]byte(`{"listing_id":1}`))
+	}))
+	defer srv.Close()
+
+	invalid := validDraft()
+	invalid.Title = ""
+
+	c, err := NewClient(srv.URL, WithValidation())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var verrs ValidationErrors
+	if _, err := c.CreateDraftListing(context.Background(), 1, invalid); !errors.As(err, &verrs) || requests != 0 {
+		t.Errorf("got %v after %d requests, want ValidationErrors before sending", err, requests)
+	}
+	if _, err := c.CreateDraftListing(context.Background(), 1, validDraft()); err != nil || requests != 1 {
+		t.Errorf("valid draft: got %v after %d requests", err, requests)
+	}
+
+	c, err = NewClient(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.CreateDraftListing(context.Background(), 1, invalid); err != nil || requests != 2 {
+		t.Errorf("without validation: got %v after %d requests, want the request sent", err, requests)
+	}
+}
+
+// fill sets every element of values to a distinct valid item
+func fill(values []string) {
+	for i := range values {
+		values[i] = string(rune('a' + i))
+	}
+}
@@ -0,0 +1,216 @@
+// Package money provides the amount/divisor/currency type Etsy uses for prices,
+// with exact integer arithmetic and locale-independent formatting.
+package money
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+var (
+	// ErrCurrencyMismatch is returned when combining amounts in different currencies
+	ErrCurrencyMismatch = errors.New("currency mismatch")
+	// ErrInvalidDivisor is returned by arithmetic on a divisor that is not a power of ten
+	ErrInvalidDivisor = errors.New("divisor is not a power of ten")
+	// ErrOverflow is returned when a result does not fit in an int64 amount
+	ErrOverflow = errors.New("money amount overflows int64")
+)
+
+// DefaultDivisor is the divisor used by FromFloat (two decimal places)
+const DefaultDivisor = 100
+
+// Money is an amount of a currency expressed as Amount / Divisor, e.g. {1250, 100, "USD"} is 12.50 USD.
+// It decodes from and encodes to Etsy's money JSON object. Etsy's divisors are powers of ten
+// (1 for JPY, 100 for USD); arithmetic rejects any other divisor.
+type Money struct {
+	Amount       int64  `json:"amount"`
+	Divisor      int64  `json:"divisor"`
+	CurrencyCode string `json:"currency_code"`
+}
+
+// New returns a Money of amount/divisor in currency
+func New(amount, divisor int64, currency string) Money {
+	return Money{Amount: amount, Divisor: divisor, CurrencyCode: currency}
+}
+
+// FromFloat converts f to a Money with two decimal places, rounding half away from zero
+func FromFloat(f float64, currency string) Money {
+	return Money{Amount: int64(math.Round(f * DefaultDivisor)), Divisor: DefaultDivisor, CurrencyCode: currency}
+}
+
+// Parse parses a decimal string such as "12.50" or "-0.005" without going through float64.
+// Only '.' is accepted as the decimal separator and no grouping separators are allowed.
+func Parse(s, currency string) (Money, error) {
+	str := strings.TrimSpace(s)
+	neg := false
+	if strings.HasPrefix(str, "-") || strings.HasPrefix(str, "+") {
+		neg = str[0] == '-'
+		str = str[1:]
+	}
+	intPart, fracPart, _ := strings.Cut(str, ".")
+	if intPart == "" && fracPart == "" || strings.Trim(intPart+fracPart, "0123456789") != "" {
+		return Money{}, fmt.Errorf("invalid money amount %q", s)
+	}
+	if len(fracPart) > 18 {
+		return Money{}, fmt.Errorf("too many decimal places in %q", s)
+	}
+
+	amount, err := strconv.ParseInt(intPart+fracPart, 10, 64)
+	if err != nil {
+		return Money{}, fmt.Errorf("invalid money amount %q: %w", s, err)
+	}
+	if neg {
+		amount = -amount
+	}
+	divisor := int64(1)
+	for range fracPart {
+		divisor *= 10
+	}
+	return Money{Amount: amount, Divisor: divisor, CurrencyCode: currency}, nil
+}
+
+func (m Money) divisor() int64 {
+	if m.Divisor <= 0 {
+		return 1
+	}
+	return m.Divisor
+}
+
+// IsZero reports whether the amount is zero
+func (m Money) IsZero() bool {
+	return m.Amount == 0
+}
+
+// Float64 returns the amount as a float64. It may lose precision; use Format for display.
+func (m Money) Float64() float64 {
+	return float64(m.Amount) / float64(m.divisor())
+}
+
+// Rat returns the exact amount as a rational number
+func (m Money) Rat() *big.Rat {
+	return big.NewRat(m.Amount, m.divisor())
+}
+
+// Format returns the amount as a plain decimal string such as "12.50" or "100" for {100, 1, "JPY"},
+// independent of locale. The number of decimals is the number of zeros of the divisor.
+// A divisor that is not a power of ten is rounded to as many decimals as it has digits.
+func (m Money) Format() string {
+	n, ok := decimals(m.divisor())
+	if !ok {
+		n = len(strconv.FormatInt(m.divisor(), 10))
+	}
+	return m.Rat().FloatString(n)
+}
+
+// String returns the amount followed by the currency code, e.g. "12.50 USD"
+func (m Money) String() string {
+	if m.CurrencyCode == "" {
+		return m.Format()
+	}
+	return m.Format() + " " + m.CurrencyCode
+}
+
+// Add returns m + o, expressed with the larger precision of the two
+func (m Money) Add(o Money) (Money, error) {
+	a, b, err := align(m, o)
+	if err != nil {
+		return Money{}, err
+	}
+	if a.Amount, err = add(a.Amount, b.Amount); err != nil {
+		return Money{}, err
+	}
+	return a, nil
+}
+
+// Sub returns m - o, expressed with the larger precision of the two
+func (m Money) Sub(o Money) (Money, error) {
+	a, b, err := align(m, o)
+	if err != nil {
+		return Money{}, err
+	}
+	if b.Amount == math.MinInt64 {
+		return Money{}, ErrOverflow
+	}
+	if a.Amount, err = add(a.Amount, -b.Amount); err != nil {
+		return Money{}, err
+	}
+	return a, nil
+}
+
+// Mul returns m multiplied by an integer quantity
+func (m Money) Mul(n int64) (Money, error) {
+	amount, err := mul(m.Amount, n)
+	if err != nil {
+		return Money{}, err
+	}
+	m.Amount = amount
+	return m, nil
+}
+
+// EncodeValues lets Money be used in form bodies and query parameters as a decimal string
+func (m Money) EncodeValues(key string, v *url.Values) error {
+	v.Set(key, m.Format())
+	return nil
+}
+
+// align converts a and b to the larger of their divisors. Both must be powers of ten,
+// so the larger one is always a multiple of the smaller and formatting stays exact.
+func align(a, b Money) (Money, Money, error) {
+	if a.CurrencyCode != "" && b.CurrencyCode != "" && a.CurrencyCode != b.CurrencyCode {
+		return Money{}, Money{}, fmt.Errorf("%w: %s and %s", ErrCurrencyMismatch, a.CurrencyCode, b.CurrencyCode)
+	}
+	if a.CurrencyCode == "" {
+		a.CurrencyCode = b.CurrencyCode
+	}
+	b.CurrencyCode = a.CurrencyCode
+
+	da, db := a.divisor(), b.divisor()
+	for _, d := range []int64{da, db} {
+		if _, ok := decimals(d); !ok {
+			return Money{}, Money{}, fmt.Errorf("%w: %d", ErrInvalidDivisor, d)
+		}
+	}
+	d := max(da, db)
+	var err error
+	if a.Amount, err = mul(a.Amount, d/da); err != nil {
+		return Money{}, Money{}, err
+	}
+	if b.Amount, err = mul(b.Amount, d/db); err != nil {
+		return Money{}, Money{}, err
+	}
+	a.Divisor, b.Divisor = d, d
+	return a, b, nil
+}
+
+// decimals returns the number of decimal places of divisor and whether it is a power of ten
+func decimals(divisor int64) (int, bool) {
+	n := 0
+	for divisor%10 == 0 {
+		divisor /= 10
+		n++
+	}
+	return n, divisor == 1
+}
+
+func add(a, b int64) (int64, error) {
+	if b > 0 && a > math.MaxInt64-b || b < 0 && a < math.MinInt64-b {
+		return 0, ErrOverflow
+	}
+	return a + b, nil
+}
+
+func mul(a, b int64) (int64, error) {
+	if a == 0 || b == 0 {
+		return 0, nil
+	}
+	c := a * b
+	if c/b != a || (a == -1 && b == math.MinInt64) || (b == -1 && a == math.MinInt64) {
+		return 0, ErrOverflow
+	}
+	return c, nil
+}
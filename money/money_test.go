@@ -0,0 +1,116 @@
+package money
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		in   string
+		want Money
+	}{
+		{"12.50", New(1250, 100, "USD")},
+		{"-0.005", New(-5, 1000, "USD")},
+		{"+3", New(3, 1, "USD")},
+		{" 7.", New(7, 1, "USD")},
+		{".5", New(5, 10, "USD")},
+	}
+	for _, tt := range tests {
+		got, err := Parse(tt.in, "USD")
+		if err != nil {
+			t.Errorf("Parse(%q): %v", tt.in, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("Parse(%q) = %+v, want %+v", tt.in, got, tt.want)
+		}
+	}
+
+	for _, in := range []string{"", "-", ".", "1,50", "1.2.3", "abc", "1.0000000000000000000", "99999999999999999999"} {
+		if _, err := Parse(in, "USD"); err == nil {
+			t.Errorf("Parse(%q) succeeded, want error", in)
+		}
+	}
+}
+
+func TestFormat(t *testing.T) {
+	tests := []struct {
+		m    Money
+		want string
+	}{
+		{New(1250, 100, "USD"), "12.50"},
+		{New(-5, 100, "USD"), "-0.05"},
+		{New(100, 1, "JPY"), "100"},
+		{New(12345, 1000, "BHD"), "12.345"},
+		{New(7, 0, "USD"), "7"},
+		{New(1, 3, "USD"), "0.3"},
+	}
+	for _, tt := range tests {
+		if got := tt.m.Format(); got != tt.want {
+			t.Errorf("%+v.Format() = %q, want %q", tt.m, got, tt.want)
+		}
+	}
+	if got := New(100, 1, "JPY").String(); got != "100 JPY" {
+		t.Errorf("String() = %q, want %q", got, "100 JPY")
+	}
+}
+
+func TestAddKeepsPrecision(t *testing.T) {
+	got, err := New(1, 10, "USD").Add(New(1, 1000, "USD"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != New(101, 1000, "USD") || got.Format() != "0.101" {
+		t.Errorf("got %+v (%s), want 0.101", got, got.Format())
+	}
+
+	got, err = New(500, 100, "USD").Sub(New(125, 100, ""))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.String() != "3.75 USD" {
+		t.Errorf("got %s, want 3.75 USD", got)
+	}
+}
+
+func TestArithmeticErrors(t *testing.T) {
+	if _, err := New(1, 100, "USD").Add(New(1, 100, "EUR")); !errors.Is(err, ErrCurrencyMismatch) {
+		t.Errorf("Add across currencies: got %v, want ErrCurrencyMismatch", err)
+	}
+	if _, err := New(1, 3, "USD").Add(New(1, 100, "USD")); !errors.Is(err, ErrInvalidDivisor) {
+		t.Errorf("Add with divisor 3: got %v, want ErrInvalidDivisor", err)
+	}
+	if _, err := New(math.MaxInt64, 1, "USD").Add(New(1, 1, "USD")); !errors.Is(err, ErrOverflow) {
+		t.Errorf("Add overflow: got %v, want ErrOverflow", err)
+	}
+	if _, err := New(math.MaxInt64, 1, "USD").Add(New(0, 100, "USD")); !errors.Is(err, ErrOverflow) {
+		t.Errorf("align overflow: got %v, want ErrOverflow", err)
+	}
+	if _, err := New(math.MinInt64, 1, "USD").Mul(-1); !errors.Is(err, ErrOverflow) {
+		t.Errorf("Mul overflow: got %v, want ErrOverflow", err)
+	}
+	if got, err := New(250, 100, "USD").Mul(3); err != nil || got.Format() != "7.50" {
+		t.Errorf("Mul = %v, %v, want 7.50", got, err)
+	}
+}
+
+func TestJSONRoundTrip(t *testing.T) {
+	in := `{"amount":1250,"divisor":100,"currency_code":"USD"}`
+	var m Money
+	if err := json.Unmarshal([]byte(in), &m); err != nil {
+		t.Fatal(err)
+	}
+	if m != New(1250, 100, "USD") {
+		t.Fatalf("decoded %+v", m)
+	}
+	out, err := json.Marshal(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(out) != in {
+		t.Errorf("encoded %s, want %s", out, in)
+	}
+}
@@ -0,0 +1,56 @@
+package oauth
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// Error codes returned by Etsy's OAuth endpoints
+const (
+	ErrorInvalidRequest = "invalid_request"
+	ErrorInvalidClient  = "invalid_client"
+	ErrorInvalidGrant   = "invalid_grant"
+	ErrorInvalidScope   = "invalid_scope"
+	ErrorAccessDenied   = "access_denied"
+)
+
+// OAuthError is an error response of the token endpoint, or an error sent back to the redirect URI
+type OAuthError struct {
+	StatusCode  int    `json:"-"`
+	Code        string `json:"error"`
+	Description string `json:"error_description"`
+	Body        []byte `json:"-"`
+}
+
+func (e *OAuthError) Error() string {
+	switch {
+	case e.Code != "" && e.Description != "":
+		return fmt.Sprintf("oauth error %s: %s", e.Code, e.Description)
+	case e.Code != "":
+		return "oauth error " + e.Code
+	default:
+		return fmt.Sprintf("oauth error: HTTP %d: %s", e.StatusCode, string(e.Body))
+	}
+}
+
+// Temporary reports whether retrying the same request later may succeed
+func (e *OAuthError) Temporary() bool {
+	return e.StatusCode == http.StatusTooManyRequests || e.StatusCode >= 500
+}
+
+// IsInvalidGrant reports whether err is an invalid_grant OAuth error,
+// which for a refresh means the refresh token was revoked or expired and the seller must reconnect
+func IsInvalidGrant(err error) bool {
+	return hasCode(err, ErrorInvalidGrant)
+}
+
+// IsAccessDenied reports whether err is an access_denied OAuth error, i.e. the seller declined to connect
+func IsAccessDenied(err error) bool {
+	return hasCode(err, ErrorAccessDenied)
+}
+
+func hasCode(err error, code string) bool {
+	var oauthErr *OAuthError
+	return errors.As(err, &oauthErr) && oauthErr.Code == code
+}
@@ -0,0 +1,109 @@
+package oauth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"sync"
+	"time"
+)
+
+// AuthorizeLocal runs the whole authorization code flow for CLI and desktop apps.
+// It listens on the loopback RedirectURI (e.g. http://localhost:3003/callback, which must be registered
+// for the app), calls open with the authorization URL (or prints it to stderr when open is nil),
+// waits for Etsy's redirect, validates the state and exchanges the code using the PKCE verifier.
+// Requests to the redirect path with a wrong state are rejected without ending the flow.
+func (c *OAuthClient) AuthorizeLocal(ctx context.Context, scopes Scopes, open func(authURL string) error) (*AccessTokenResponse, error) {
+	redirect, err := url.Parse(c.RedirectURI)
+	if err != nil {
+		return nil, fmt.Errorf("invalid redirect URI: %w", err)
+	}
+	if redirect.Scheme != "http" || !isLoopback(redirect.Hostname()) || redirect.Port() == "" {
+		return nil, fmt.Errorf("redirect URI %q must be an http loopback URL with a port", c.RedirectURI)
+	}
+
+	authReq, err := c.Connect(scopes, "")
+	if err != nil {
+		return nil, err
+	}
+
+	ln, err := net.Listen("tcp", redirect.Host)
+	if err != nil {
+		return nil, err
+	}
+
+	type result struct {
+		code string
+		err  error
+	}
+	results := make(chan result, 1)
+	var once sync.Once
+
+	path := redirect.Path
+	if path == "" {
+		path = "/"
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != path {
+			http.NotFound(w, r)
+			return
+		}
+		code, err := c.ValidateCallback(authReq, r.URL.Query())
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		if errors.Is(err, ErrStateMismatch) {
+			// Not the redirect for this flow (a stray or forged request); keep waiting for the real one.
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, "<p>Authorization failed: state mismatch</p>")
+			return
+		}
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprintf(w, "<p>Authorization failed: %s</p>", html.EscapeString(err.Error()))
+		} else {
+			fmt.Fprint(w, "<p>Authorization complete. You can close this window.</p>")
+		}
+		once.Do(func() { results <- result{code: code, err: err} })
+	})
+
+	srv := &http.Server{Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+	go srv.Serve(ln)
+	defer func() {
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		srv.Shutdown(shutdownCtx)
+	}()
+
+	if open != nil {
+		if err := open(authReq.URL); err != nil {
+			return nil, err
+		}
+	} else {
+		fmt.Fprintf(os.Stderr, "Open the following URL in your browser to authorize the app:\n\n%s\n\n", authReq.URL)
+	}
+
+	var res result
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case res = <-results:
+	}
+	if res.err != nil {
+		return nil, res.err
+	}
+
+	return c.ExchangeCodeContext(ctx, res.code, authReq.CodeVerifier)
+}
+
+func isLoopback(host string) bool {
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
@@ -0,0 +1,110 @@
+package oauth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+)
+
+// newLoopbackClient returns a client redirecting to a free loopback port and exchanging codes with a fake token endpoint.
+// The endpoint checks that the code verifier matches the challenge of the last authorization URL.
+func newLoopbackClient(t *testing.T, challenge *string) *OAuthClient {
+	t.Helper()
+
+	tokenSrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			t.Error(err)
+		}
+		if got := r.PostForm.Get("grant_type"); got != "authorization_code" {
+			t.Errorf("grant_type = %q", got)
+		}
+		if got := r.PostForm.Get("code"); got != "the-code" {
+			t.Errorf("code = %q", got)
+		}
+		if verifier := r.PostForm.Get("code_verifier"); verifier == "" || GenerateCodeChallenge(verifier) != *challenge {
+			t.Errorf("code_verifier %q does not match challenge %q", verifier, *challenge)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"access_token":"1.access","refresh_token":"1.refresh","token_type":"Bearer","expires_in":3600,"scope":"listings_r"}`)
+	}))
+	t.Cleanup(tokenSrv.Close)
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := ln.Addr().String()
+	ln.Close()
+
+	c := NewOAuthClient("client-id", "http://"+addr+"/callback")
+	c.TokenURL = tokenSrv.URL
+	return c
+}
+
+// callback sends the browser redirect for authURL with the given state and returns the status code
+func callback(t *testing.T, c *OAuthClient, state string) int {
+	t.Helper()
+
+	q := url.Values{"state": {state}, "code": {"the-code"}}
+	rsp, err := http.Get(c.RedirectURI + "?" + q.Encode())
+	if err != nil {
+		t.Fatal(err)
+	}
+	rsp.Body.Close()
+	return rsp.StatusCode
+}
+
+func TestAuthorizeLocal(t *testing.T) {
+	var challenge string
+	c := newLoopbackClient(t, &challenge)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	token, err := c.AuthorizeLocal(ctx, Scopes{ScopeListingsRead}, func(authURL string) error {
+		u, err := url.Parse(authURL)
+		if err != nil {
+			return err
+		}
+		challenge = u.Query().Get("code_challenge")
+
+		// A stray request with the wrong state must not end the flow.
+		if status := callback(t, c, "forged"); status != http.StatusBadRequest {
+			t.Errorf("forged callback: status %d, want %d", status, http.StatusBadRequest)
+		}
+		if status := callback(t, c, u.Query().Get("state")); status != http.StatusOK {
+			t.Errorf("callback: status %d, want %d", status, http.StatusOK)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if token.AccessToken != "1.access" || token.RefreshToken != "1.refresh" {
+		t.Errorf("got token %+v", token)
+	}
+}
+
+func TestAuthorizeLocalWrongState(t *testing.T) {
+	var challenge string
+	c := newLoopbackClient(t, &challenge)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+
+	_, err := c.AuthorizeLocal(ctx, Scopes{ScopeListingsRead}, func(authURL string) error {
+		if status := callback(t, c, "forged"); status != http.StatusBadRequest {
+			t.Errorf("forged callback: status %d, want %d", status, http.StatusBadRequest)
+		}
+		return nil
+	})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("got %v, want the flow to keep waiting until the deadline", err)
+	}
+}
@@ -2,16 +2,22 @@ package oauth
 
 import (
 	"bytes"
+	"context"
 	"crypto/rand"
 	"crypto/sha256"
+	"crypto/subtle"
 	"encoding/base64"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"io"
-	"log"
 	"net/http"
 	"net/url"
-	"strings"
+)
+
+const (
+	DefaultAuthURL  = "https://www.etsy.com/oauth/connect"
+	DefaultTokenURL = "https://api.etsy.com/v3/public/oauth/token"
 )
 
 type OAuthClient struct {
@@ -19,6 +25,11 @@ type OAuthClient struct {
 	ClientSecret string
 	RedirectURI  string
 	HTTPClient   *http.Client
+
+	// AuthURL and TokenURL override Etsy's endpoints, e.g. for local fakes or staging.
+	// Empty values use DefaultAuthURL and DefaultTokenURL.
+	AuthURL  string
+	TokenURL string
 }
 
 type AccessTokenResponse struct {
@@ -52,12 +63,55 @@ func GenerateCodeChallenge(verifier string) string {
 	return base64.RawURLEncoding.EncodeToString(hash[:])
 }
 
-func (c *OAuthClient) Connect(codeVerifier string, scopes []string) string {
-	baseURL := "https://www.etsy.com/oauth/connect"
+// AuthRequest is an authorization request started by Connect.
+// Keep it (e.g. in the user's session) until Etsy redirects back, then pass it to ValidateCallback
+// and use CodeVerifier with ExchangeCode.
+type AuthRequest struct {
+	URL          string
+	State        string
+	CodeVerifier string
+}
+
+var (
+	// ErrStateMismatch is returned by ValidateCallback when the state echoed by Etsy is not the one sent
+	ErrStateMismatch = errors.New("oauth state mismatch")
+	// ErrMissingCode is returned by ValidateCallback when the redirect carries neither a code nor an error
+	ErrMissingCode = errors.New("authorization code missing from callback")
+	// ErrInvalidAuthRequest is returned by ValidateCallback when the AuthRequest is nil or has no state,
+	// e.g. because it was not restored from the session correctly
+	ErrInvalidAuthRequest = errors.New("auth request has no state")
+)
+
+// GenerateState returns a random value for the OAuth state parameter
+func GenerateState() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// Connect starts an authorization request: it generates a PKCE code verifier and,
+// if state is empty, a random state, and returns them with the Etsy authorization URL.
+func (c *OAuthClient) Connect(scopes Scopes, state string) (*AuthRequest, error) {
+	baseURL := c.AuthURL
+	if baseURL == "" {
+		baseURL = DefaultAuthURL
+	}
 
 	u, err := url.Parse(baseURL)
 	if err != nil {
-		log.Fatal("Invalid base URL:", err)
+		return nil, fmt.Errorf("invalid auth URL: %w", err)
+	}
+
+	codeVerifier, err := GenerateCodeVerifier()
+	if err != nil {
+		return nil, err
+	}
+	if state == "" {
+		if state, err = GenerateState(); err != nil {
+			return nil, err
+		}
 	}
 
 	codeChallenge := GenerateCodeChallenge(codeVerifier)
@@ -65,16 +119,41 @@ func (c *OAuthClient) Connect(codeVerifier string, scopes []string) string {
 	q.Set("response_type", "code")
 	q.Set("client_id", c.ClientID)
 	q.Set("redirect_uri", c.RedirectURI)
-	q.Set("scope", strings.Join(scopes, " "))
-	q.Set("state", "superstate") //state=superstate assigns the state string to superstate which Etsy.com should return with the authorization code
+	q.Set("scope", scopes.String())
+	q.Set("state", state) // Etsy returns the state with the authorization code; ValidateCallback checks it
 	q.Set("code_challenge", codeChallenge)
 	q.Set("code_challenge_method", "S256")
 
 	u.RawQuery = q.Encode()
-	return u.String()
+	return &AuthRequest{URL: u.String(), State: state, CodeVerifier: codeVerifier}, nil
+}
+
+// ValidateCallback checks the query of the redirect Etsy sent back for authReq
+// and returns the authorization code to pass to ExchangeCode.
+func (c *OAuthClient) ValidateCallback(authReq *AuthRequest, query url.Values) (string, error) {
+	// An empty state would match a callback without one and defeat the CSRF check.
+	if authReq == nil || authReq.State == "" {
+		return "", ErrInvalidAuthRequest
+	}
+	if subtle.ConstantTimeCompare([]byte(query.Get("state")), []byte(authReq.State)) != 1 {
+		return "", ErrStateMismatch
+	}
+	if e := query.Get("error"); e != "" {
+		return "", &OAuthError{Code: e, Description: query.Get("error_description")}
+	}
+	code := query.Get("code")
+	if code == "" {
+		return "", ErrMissingCode
+	}
+	return code, nil
 }
 
 func (c *OAuthClient) ExchangeCode(code, codeVerifier string) (*AccessTokenResponse, error) {
+	return c.ExchangeCodeContext(context.Background(), code, codeVerifier)
+}
+
+// ExchangeCodeContext is ExchangeCode with a context for cancellation and deadlines
+func (c *OAuthClient) ExchangeCodeContext(ctx context.Context, code, codeVerifier string) (*AccessTokenResponse, error) {
 	form := url.Values{}
 	form.Set("grant_type", "authorization_code")
 	form.Set("client_id", c.ClientID)
@@ -82,44 +161,41 @@ func (c *OAuthClient) ExchangeCode(code, codeVerifier string) (*AccessTokenRespo
 	form.Set("code", code)
 	form.Set("code_verifier", codeVerifier)
 
-	req, err := http.NewRequest("POST", "https://api.etsy.com/v3/public/oauth/token", bytes.NewBufferString(form.Encode()))
-	if err != nil {
-		return nil, err
-	}
-
-	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
-	resp, err := c.HTTPClient.Do(req)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	body, _ := io.ReadAll(resp.Body)
-	if resp.StatusCode != http.StatusOK {
-		return nil, errors.New(string(body))
-	}
-
-	var tokenResp AccessTokenResponse
-	if err := json.Unmarshal(body, &tokenResp); err != nil {
-		return nil, err
-	}
-
-	return &tokenResp, nil
+	return c.requestToken(ctx, form)
 }
 
 func (c *OAuthClient) RefreshToken(refreshToken string) (*AccessTokenResponse, error) {
+	return c.RefreshTokenContext(context.Background(), refreshToken)
+}
+
+// RefreshTokenContext is RefreshToken with a context for cancellation and deadlines
+func (c *OAuthClient) RefreshTokenContext(ctx context.Context, refreshToken string) (*AccessTokenResponse, error) {
 	form := url.Values{}
 	form.Set("grant_type", "refresh_token")
 	form.Set("refresh_token", refreshToken)
 	form.Set("client_id", c.ClientID)
 
-	req, err := http.NewRequest("POST", "https://api.etsy.com/v3/public/oauth/token", bytes.NewBufferString(form.Encode()))
+	return c.requestToken(ctx, form)
+}
+
+func (c *OAuthClient) requestToken(ctx context.Context, form url.Values) (*AccessTokenResponse, error) {
+	tokenURL := c.TokenURL
+	if tokenURL == "" {
+		tokenURL = DefaultTokenURL
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", tokenURL, bytes.NewBufferString(form.Encode()))
 	if err != nil {
 		return nil, err
 	}
 
+	httpClient := c.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
 	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
-	resp, err := c.HTTPClient.Do(req)
+	resp, err := httpClient.Do(req)
 	if err != nil {
 		return nil, err
 	}
@@ -127,7 +203,9 @@ func (c *OAuthClient) RefreshToken(refreshToken string) (*AccessTokenResponse, e
 
 	body, _ := io.ReadAll(resp.Body)
 	if resp.StatusCode != http.StatusOK {
-		return nil, errors.New(string(body))
+		oauthErr := &OAuthError{StatusCode: resp.StatusCode, Body: body}
+		json.Unmarshal(body, oauthErr) // non-JSON bodies are kept in Body
+		return nil, oauthErr
 	}
 
 	var tokenResp AccessTokenResponse
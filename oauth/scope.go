@@ -0,0 +1,99 @@
+package oauth
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// Scopes is a set of OAuth scopes, as requested in Connect or granted in AccessTokenResponse
+type Scopes []Scope
+
+// ParseScopes parses a space separated scope string such as the one returned by the token endpoint
+func ParseScopes(s string) Scopes {
+	fields := strings.Fields(s)
+	if len(fields) == 0 {
+		return nil
+	}
+	scopes := make(Scopes, len(fields))
+	for i, f := range fields {
+		scopes[i] = Scope(f)
+	}
+	return scopes
+}
+
+// Has reports whether scope is in the set
+func (s Scopes) Has(scope Scope) bool {
+	for _, granted := range s {
+		if granted == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// Missing returns the scopes of required that are not in the set
+func (s Scopes) Missing(required ...Scope) Scopes {
+	var missing Scopes
+	for _, scope := range required {
+		if !s.Has(scope) {
+			missing = append(missing, scope)
+		}
+	}
+	return missing
+}
+
+// String returns the scopes space separated, as expected by the authorization endpoint
+func (s Scopes) String() string {
+	parts := make([]string, len(s))
+	for i, scope := range s {
+		parts[i] = string(scope)
+	}
+	return strings.Join(parts, " ")
+}
+
+// Scopes returns the scopes granted to the token
+func (r AccessTokenResponse) Scopes() Scopes {
+	return ParseScopes(r.Scope)
+}
+
+// Operation describes an Etsy API operation and the scopes it requires.
+// The API clients attach it to the request context so authorizers can check it before sending.
+type Operation struct {
+	// Name is Etsy's operation ID, e.g. "updateShopReceipt"
+	Name   string
+	Scopes Scopes
+	// Public operations only need the API key; all others need an OAuth access token.
+	Public bool
+}
+
+type operationKey struct{}
+
+// WithOperation returns a context carrying op
+func WithOperation(ctx context.Context, op Operation) context.Context {
+	return context.WithValue(ctx, operationKey{}, op)
+}
+
+// OperationFromContext returns the operation attached to ctx by WithOperation
+func OperationFromContext(ctx context.Context) (Operation, bool) {
+	op, ok := ctx.Value(operationKey{}).(Operation)
+	return op, ok
+}
+
+// ScopeError is returned when the token lacks scopes required by an operation
+type ScopeError struct {
+	Operation string
+	Missing   Scopes
+}
+
+func (e *ScopeError) Error() string {
+	return fmt.Sprintf("operation %s requires scopes not granted to the token: %s", e.Operation, e.Missing)
+}
+
+// CheckScopes returns a *ScopeError if granted lacks any scope required by op
+func CheckScopes(op Operation, granted Scopes) error {
+	if missing := granted.Missing(op.Scopes...); len(missing) > 0 {
+		return &ScopeError{Operation: op.Name, Missing: missing}
+	}
+	return nil
+}
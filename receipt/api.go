@@ -10,6 +10,8 @@ import (
 	runt "runtime"
 	"strings"
 
+	"github.com/dzt-corp/go-etsy/oauth"
+	"github.com/dzt-corp/go-etsy/response"
 	"github.com/google/go-querystring/query"
 )
 
@@ -49,6 +51,9 @@ type Client struct {
 	// The user agent header identifies your application, its version number, and the platform and programming language you are using.
 	// You must include a user agent header in each request submitted to the sales partner API.
 	UserAgent string
+
+	// ValidateRequests checks the sort_on and sort_order parameters of GetShopReceipts before sending them.
+	ValidateRequests bool
 }
 
 // ClientOption allows setting custom parameters during construction
@@ -99,6 +104,14 @@ func WithUserAgent(userAgent string) ClientOption {
 	}
 }
 
+// WithValidation enables client-side checks of the enum parameters of GetShopReceipts
+func WithValidation() ClientOption {
+	return func(c *Client) error {
+		c.ValidateRequests = true
+		return nil
+	}
+}
+
 // WithRequestBefore allows setting up a callback function, which will be
 // called right before sending the request. This can be used to mutate the request.
 func WithRequestBefore(fn RequestBeforeFn) ClientOption {
@@ -135,6 +148,12 @@ type ClientInterface interface {
 
 // GetOrdersWithResponse request returning *GetOrdersResponse
 func (c *Client) GetShopReceipts(ctx context.Context, shopID int64, params *GetShopReceiptsParams) (*ReceiptListResponse, error) {
+	ctx = oauth.WithOperation(ctx, opGetShopReceipts)
+	if c.ValidateRequests {
+		if err := params.checkEnums(); err != nil {
+			return nil, err
+		}
+	}
 	req, err := NewGetShopReceiptsRequest(c.Endpoint, shopID, params)
 	if err != nil {
 		return nil, err
@@ -154,6 +173,10 @@ func (c *Client) GetShopReceipts(ctx context.Context, shopID int64, params *GetS
 		return nil, err
 	}
 
+	if err := response.Capture(ctx, rsp); err != nil {
+		return nil, err
+	}
+
 	if c.ResponseAfter != nil {
 		err = c.ResponseAfter(ctx, rsp)
 		if err != nil {
@@ -221,6 +244,7 @@ func ParseGetShopReceiptsResp(rsp *http.Response) (*ReceiptListResponse, error)
 // GetShopReceipt fetches a single receipt by its receipt_id
 // https://openapi.etsy.com/v3/application/shops/{shop_id}/receipts/{receipt_id}
 func (c *Client) GetShopReceipt(ctx context.Context, shopID, receiptID int64) (*Receipt, error) {
+	ctx = oauth.WithOperation(ctx, opGetShopReceipt)
 	req, err := NewGetShopReceiptRequest(c.Endpoint, shopID, receiptID)
 	if err != nil {
 		return nil, err
@@ -239,6 +263,10 @@ func (c *Client) GetShopReceipt(ctx context.Context, shopID, receiptID int64) (*
 		return nil, err
 	}
 
+	if err := response.Capture(ctx, rsp); err != nil {
+		return nil, err
+	}
+
 	if c.ResponseAfter != nil {
 		if err := c.ResponseAfter(ctx, rsp); err != nil {
 			return nil, err
@@ -294,6 +322,7 @@ func ParseGetShopReceiptResp(rsp *http.Response) (*Receipt, error) {
 
 // UpdateShopReceipt updates receipt fields such as was_paid, was_shipped, etc.
 func (c *Client) UpdateShopReceipt(ctx context.Context, shopID, receiptID int64, body UpdateShopReceiptBody) (*Receipt, error) {
+	ctx = oauth.WithOperation(ctx, opUpdateShopReceipt)
 	req, err := NewUpdateShopReceiptRequest(c.Endpoint, shopID, receiptID, body)
 	if err != nil {
 		return nil, err
@@ -314,6 +343,10 @@ func (c *Client) UpdateShopReceipt(ctx context.Context, shopID, receiptID int64,
 		return nil, err
 	}
 
+	if err := response.Capture(ctx, resp); err != nil {
+		return nil, err
+	}
+
 	if c.ResponseAfter != nil {
 		if err := c.ResponseAfter(ctx, resp); err != nil {
 			return nil, err
@@ -350,6 +383,7 @@ func NewUpdateShopReceiptRequest(endpoint string, shopID, receiptID int64, body
 
 // CreateReceiptShipment creates a shipment and sends a notification for the given receipt
 func (c *Client) CreateReceiptShipment(ctx context.Context, shopID, receiptID int64, body CreateReceiptShipmentBody) (*Receipt, error) {
+	ctx = oauth.WithOperation(ctx, opCreateReceiptShipment)
 	req, err := NewCreateReceiptShipmentRequest(c.Endpoint, shopID, receiptID, body)
 	if err != nil {
 		return nil, err
@@ -370,6 +404,10 @@ func (c *Client) CreateReceiptShipment(ctx context.Context, shopID, receiptID in
 		return nil, err
 	}
 
+	if err := response.Capture(ctx, resp); err != nil {
+		return nil, err
+	}
+
 	if c.ResponseAfter != nil {
 		if err := c.ResponseAfter(ctx, resp); err != nil {
 			return nil, err
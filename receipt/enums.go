@@ -0,0 +1,57 @@
+package receipt
+
+import (
+	"errors"
+	"fmt"
+)
+
+// SortOn is the field receipts are sorted by
+type SortOn string
+
+const (
+	SortOnCreated   SortOn = "created"
+	SortOnUpdated   SortOn = "updated"
+	SortOnReceiptID SortOn = "receipt_id"
+)
+
+func (s SortOn) Valid() bool {
+	switch s {
+	case SortOnCreated, SortOnUpdated, SortOnReceiptID:
+		return true
+	}
+	return false
+}
+
+// SortOrder is the direction receipts are sorted in
+type SortOrder string
+
+const (
+	SortOrderAsc        SortOrder = "asc"
+	SortOrderAscending  SortOrder = "ascending"
+	SortOrderDesc       SortOrder = "desc"
+	SortOrderDescending SortOrder = "descending"
+	SortOrderUp         SortOrder = "up"
+	SortOrderDown       SortOrder = "down"
+)
+
+func (s SortOrder) Valid() bool {
+	switch s {
+	case SortOrderAsc, SortOrderAscending, SortOrderDesc, SortOrderDescending, SortOrderUp, SortOrderDown:
+		return true
+	}
+	return false
+}
+
+func (p *GetShopReceiptsParams) checkEnums() error {
+	if p == nil {
+		return nil
+	}
+	var errs []error
+	if p.SortOn != nil && !p.SortOn.Valid() {
+		errs = append(errs, fmt.Errorf("invalid sort_on %q", string(*p.SortOn)))
+	}
+	if p.SortOrder != nil && !p.SortOrder.Valid() {
+		errs = append(errs, fmt.Errorf("invalid sort_order %q", string(*p.SortOrder)))
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,72 @@
+package receipt
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestEnumValid(t *testing.T) {
+	for _, s := range []SortOn{SortOnCreated, SortOnUpdated, SortOnReceiptID} {
+		if !s.Valid() {
+			t.Errorf("SortOn %q is not valid", s)
+		}
+	}
+	for _, s := range []SortOrder{SortOrderAsc, SortOrderAscending, SortOrderDesc, SortOrderDescending, SortOrderUp, SortOrderDown} {
+		if !s.Valid() {
+			t.Errorf("SortOrder %q is not valid", s)
+		}
+	}
+	if SortOn("price").Valid() || SortOn("").Valid() {
+		t.Error("unknown SortOn is valid")
+	}
+	if SortOrder("sideways").Valid() {
+		t.Error("unknown SortOrder is valid")
+	}
+}
+
+func TestCheckEnums(t *testing.T) {
+	if err := (*GetShopReceiptsParams)(nil).checkEnums(); err != nil {
+		t.Errorf("nil params: %v", err)
+	}
+	on, order := SortOnUpdated, SortOrderAsc
+	if err := (&GetShopReceiptsParams{SortOn: &on, SortOrder: &order}).checkEnums(); err != nil {
+		t.Errorf("valid params: %v", err)
+	}
+
+	badOn, badOrder := SortOn("price"), SortOrder("sideways")
+	err := (&GetShopReceiptsParams{SortOn: &badOn, SortOrder: &badOrder}).checkEnums()
+	if err == nil || !strings.Contains(err.Error(), `invalid sort_on "price"`) || !strings.Contains(err.Error(), `invalid sort_order "sideways"`) {
+		t.Errorf("invalid params: got %v", err)
+	}
+}
+
+func TestEnumChecksOnlyWithValidation(t *testing.T) {
+	requests := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		fmt.Fprint(w, `{"count":0,"results":[]}`)
+	}))
+	defer srv.Close()
+	on := SortOn("paid")
+	params := &GetShopReceiptsParams{SortOn: &on}
+
+	c, err := NewClient(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.GetShopReceipts(context.Background(), 1, params); err != nil || requests != 1 {
+		t.Errorf("without validation: err %v after %d requests, want the request sent", err, requests)
+	}
+
+	c, err = NewClient(srv.URL, WithValidation())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.GetShopReceipts(context.Background(), 1, params); err == nil || requests != 1 {
+		t.Errorf("with validation: err %v after %d requests, want an error before sending", err, requests)
+	}
+}
@@ -0,0 +1,11 @@
+package receipt
+
+import "github.com/dzt-corp/go-etsy/oauth"
+
+// Etsy operations of the receipt API and the OAuth scopes they require
+var (
+	opGetShopReceipts       = oauth.Operation{Name: "getShopReceipts", Scopes: oauth.Scopes{oauth.ScopeTransactionsRead}}
+	opGetShopReceipt        = oauth.Operation{Name: "getShopReceipt", Scopes: oauth.Scopes{oauth.ScopeTransactionsRead}}
+	opUpdateShopReceipt     = oauth.Operation{Name: "updateShopReceipt", Scopes: oauth.Scopes{oauth.ScopeTransactionsWrite}}
+	opCreateReceiptShipment = oauth.Operation{Name: "createReceiptShipment", Scopes: oauth.Scopes{oauth.ScopeTransactionsWrite}}
+)
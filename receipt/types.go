@@ -1,5 +1,15 @@
 package receipt
 
+import (
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/dzt-corp/go-etsy/internal/jsonextra"
+	"github.com/dzt-corp/go-etsy/money"
+	"github.com/dzt-corp/go-etsy/timestamp"
+)
+
 // GetShopReceiptsParams defines the query parameters for Etsy's GET /shops/{shop_id}/receipts endpoint.
 type GetShopReceiptsParams struct {
 	// The earliest created date for a receipt (UNIX timestamp). Minimum: 946684800
@@ -21,10 +31,10 @@ type GetShopReceiptsParams struct {
 	Offset *int `url:"offset,omitempty"`
 
 	// Field to sort by. One of: "created", "updated", "receipt_id". Default: "created"
-	SortOn *string `url:"sort_on,omitempty"`
+	SortOn *SortOn `url:"sort_on,omitempty"`
 
 	// Sorting order. One of: "asc", "ascending", "desc", "descending", "up", "down". Default: "desc"
-	SortOrder *string `url:"sort_order,omitempty"`
+	SortOrder *SortOrder `url:"sort_order,omitempty"`
 
 	// If true, return only receipts that have been paid.
 	// If false, return only unpaid receipts. Nullable.
@@ -46,19 +56,67 @@ type GetShopReceiptsParams struct {
 	Legacy *bool `url:"legacy,omitempty"`
 }
 
-type Money struct {
-	Amount       int64  `json:"amount"`
-	Divisor      int64  `json:"divisor"`
-	CurrencyCode string `json:"currency_code"`
+// WithCreatedBetween sets MinCreated and MaxCreated from from and to. A zero bound is left unset.
+// It allocates the params when p is nil and returns p for chaining.
+func (p *GetShopReceiptsParams) WithCreatedBetween(from, to time.Time) *GetShopReceiptsParams {
+	if p == nil {
+		p = &GetShopReceiptsParams{}
+	}
+	p.MinCreated, p.MaxCreated = unixRange(from, to)
+	return p
 }
 
+// WithLastModifiedBetween sets MinLastModified and MaxLastModified from from and to. A zero bound is left unset.
+// It allocates the params when p is nil and returns p for chaining.
+func (p *GetShopReceiptsParams) WithLastModifiedBetween(from, to time.Time) *GetShopReceiptsParams {
+	if p == nil {
+		p = &GetShopReceiptsParams{}
+	}
+	p.MinLastModified, p.MaxLastModified = unixRange(from, to)
+	return p
+}
+
+func unixRange(from, to time.Time) (min, max *int64) {
+	if !from.IsZero() {
+		v := from.Unix()
+		min = &v
+	}
+	if !to.IsZero() {
+		v := to.Unix()
+		max = &v
+	}
+	return min, max
+}
+
+// Money is kept as an alias of money.Money for backwards compatibility
+type Money = money.Money
+
 type ReceiptShipment struct {
-	ReceiptShippingID             int64  `json:"receipt_shipping_id"`
-	ShipmentNotificationTimestamp int64  `json:"shipment_notification_timestamp"`
-	CarrierName                   string `json:"carrier_name"`
-	TrackingCode                  string `json:"tracking_code"`
+	ReceiptShippingID             int64               `json:"receipt_shipping_id"`
+	ShipmentNotificationTimestamp timestamp.Timestamp `json:"shipment_notification_timestamp"`
+	CarrierName                   string              `json:"carrier_name"`
+	TrackingCode                  string              `json:"tracking_code"`
+
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
+func (s *ReceiptShipment) UnmarshalJSON(data []byte) error {
+	type plain ReceiptShipment
+	return jsonextra.UnmarshalInto(data, (*plain)(s), &s.Extra)
+}
+
+func (s ReceiptShipment) MarshalJSON() ([]byte, error) {
+	type plain ReceiptShipment
+	return jsonextra.MarshalWith(plain(s), s.Extra)
+}
+
+// PersonalizationPropertyID is the property_id of the "Personalization" entry in transaction variations.
+// Etsy does not document it; it is the value getShopReceipts returns for personalized listings,
+// so Personalization also matches on FormattedName in case it changes.
+const PersonalizationPropertyID = 54
+
+// TransactionVariation is a variation chosen or entered by the buyer.
+// ValueID is 0 for values typed in by the buyer, such as personalization.
 type TransactionVariation struct {
 	PropertyID     int64  `json:"property_id"`
 	ValueID        int64  `json:"value_id"`
@@ -81,10 +139,10 @@ type ReceiptTransaction struct {
 	Description       string                   `json:"description"`
 	SellerUserID      int64                    `json:"seller_user_id"`
 	BuyerUserID       int64                    `json:"buyer_user_id"`
-	CreateTimestamp   int64                    `json:"create_timestamp"`
-	CreatedTimestamp  int64                    `json:"created_timestamp"`
-	PaidTimestamp     int64                    `json:"paid_timestamp"`
-	ShippedTimestamp  int64                    `json:"shipped_timestamp"`
+	CreateTimestamp   timestamp.Timestamp      `json:"create_timestamp"`
+	CreatedTimestamp  timestamp.Timestamp      `json:"created_timestamp"`
+	PaidTimestamp     timestamp.Timestamp      `json:"paid_timestamp"`
+	ShippedTimestamp  timestamp.Timestamp      `json:"shipped_timestamp"`
 	Quantity          int                      `json:"quantity"`
 	ListingImageID    int64                    `json:"listing_image_id"`
 	ReceiptID         int64                    `json:"receipt_id"`
@@ -103,34 +161,84 @@ type ReceiptTransaction struct {
 	MaxProcessingDays int                      `json:"max_processing_days"`
 	ShippingMethod    string                   `json:"shipping_method"`
 	ShippingUpgrade   string                   `json:"shipping_upgrade"`
-	ExpectedShipDate  int64                    `json:"expected_ship_date"`
+	ExpectedShipDate  timestamp.Timestamp      `json:"expected_ship_date"`
 	BuyerCoupon       float64                  `json:"buyer_coupon"`
 	ShopCoupon        float64                  `json:"shop_coupon"`
+
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// Personalization returns the personalization text entered by the buyer, if any
+func (t ReceiptTransaction) Personalization() (string, bool) {
+	for _, v := range t.Variations {
+		if v.PropertyID == PersonalizationPropertyID || strings.EqualFold(v.FormattedName, "Personalization") {
+			return v.FormattedValue, true
+		}
+	}
+	return "", false
+}
+
+// BuyerEnteredVariations returns the variations whose value was typed in by the buyer
+// rather than selected from the listing's property values
+func (t ReceiptTransaction) BuyerEnteredVariations() []TransactionVariation {
+	var entered []TransactionVariation
+	for _, v := range t.Variations {
+		if v.ValueID == 0 {
+			entered = append(entered, v)
+		}
+	}
+	return entered
+}
+
+func (t *ReceiptTransaction) UnmarshalJSON(data []byte) error {
+	type plain ReceiptTransaction
+	return jsonextra.UnmarshalInto(data, (*plain)(t), &t.Extra)
+}
+
+func (t ReceiptTransaction) MarshalJSON() ([]byte, error) {
+	type plain ReceiptTransaction
+	return jsonextra.MarshalWith(plain(t), t.Extra)
 }
 
 type ReceiptRefund struct {
-	Amount           Money  `json:"amount"`
-	CreatedTimestamp int64  `json:"created_timestamp"`
-	Reason           string `json:"reason"`
-	NoteFromIssuer   string `json:"note_from_issuer"`
-	Status           string `json:"status"`
+	Amount           Money               `json:"amount"`
+	CreatedTimestamp timestamp.Timestamp `json:"created_timestamp"`
+	Reason           string              `json:"reason"`
+	NoteFromIssuer   string              `json:"note_from_issuer"`
+	Status           string              `json:"status"`
+
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+func (r *ReceiptRefund) UnmarshalJSON(data []byte) error {
+	type plain ReceiptRefund
+	return jsonextra.UnmarshalInto(data, (*plain)(r), &r.Extra)
 }
 
+func (r ReceiptRefund) MarshalJSON() ([]byte, error) {
+	type plain ReceiptRefund
+	return jsonextra.MarshalWith(plain(r), r.Extra)
+}
+
+// Receipt is a shop receipt. Like the other models in this package,
+// it keeps the JSON members it does not declare in Extra.
 type Receipt struct {
-	ReceiptID          int64                `json:"receipt_id"`
-	ReceiptType        int                  `json:"receipt_type"`
-	SellerUserID       int64                `json:"seller_user_id"`
-	SellerEmail        string               `json:"seller_email"`
-	BuyerUserID        int64                `json:"buyer_user_id"`
-	BuyerEmail         string               `json:"buyer_email"`
-	Name               string               `json:"name"`
-	FirstLine          string               `json:"first_line"`
-	SecondLine         string               `json:"second_line"`
-	City               string               `json:"city"`
-	State              string               `json:"state"`
-	Zip                string               `json:"zip"`
-	Status             string               `json:"status"`
-	FormattedAddress   string               `json:"formatted_address"`
+	ReceiptID        int64  `json:"receipt_id"`
+	ReceiptType      int    `json:"receipt_type"`
+	SellerUserID     int64  `json:"seller_user_id"`
+	SellerEmail      string `json:"seller_email"`
+	BuyerUserID      int64  `json:"buyer_user_id"`
+	BuyerEmail       string `json:"buyer_email"`
+	Name             string `json:"name"`
+	FirstLine        string `json:"first_line"`
+	SecondLine       string `json:"second_line"`
+	City             string `json:"city"`
+	State            string `json:"state"`
+	Zip              string `json:"zip"`
+	Status           string `json:"status"`
+	FormattedAddress string `json:"formatted_address"`
+	// CountryISO is the ISO-3166 alpha-2 code of the buyer's country.
+	// Etsy v3 does not return the country name; it is only part of FormattedAddress.
 	CountryISO         string               `json:"country_iso"`
 	PaymentMethod      string               `json:"payment_method"`
 	PaymentEmail       string               `json:"payment_email"`
@@ -139,10 +247,10 @@ type Receipt struct {
 	MessageFromPayment string               `json:"message_from_payment"`
 	IsPaid             bool                 `json:"is_paid"`
 	IsShipped          bool                 `json:"is_shipped"`
-	CreateTimestamp    int64                `json:"create_timestamp"`
-	CreatedTimestamp   int64                `json:"created_timestamp"`
-	UpdateTimestamp    int64                `json:"update_timestamp"`
-	UpdatedTimestamp   int64                `json:"updated_timestamp"`
+	CreateTimestamp    timestamp.Timestamp  `json:"create_timestamp"`
+	CreatedTimestamp   timestamp.Timestamp  `json:"created_timestamp"`
+	UpdateTimestamp    timestamp.Timestamp  `json:"update_timestamp"`
+	UpdatedTimestamp   timestamp.Timestamp  `json:"updated_timestamp"`
 	IsGift             bool                 `json:"is_gift"`
 	GiftMessage        string               `json:"gift_message"`
 	GiftSender         string               `json:"gift_sender"`
@@ -157,6 +265,19 @@ type Receipt struct {
 	Shipments          []ReceiptShipment    `json:"shipments"`
 	Transactions       []ReceiptTransaction `json:"transactions"`
 	Refunds            []ReceiptRefund      `json:"refunds"`
+	NeedsGiftWrap      bool                 `json:"needs_gift_wrap"`
+
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+func (r *Receipt) UnmarshalJSON(data []byte) error {
+	type plain Receipt
+	return jsonextra.UnmarshalInto(data, (*plain)(r), &r.Extra)
+}
+
+func (r Receipt) MarshalJSON() ([]byte, error) {
+	type plain Receipt
+	return jsonextra.MarshalWith(plain(r), r.Extra)
 }
 
 type ReceiptListResponse struct {
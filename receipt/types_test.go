@@ -0,0 +1,27 @@
+package receipt
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-querystring/query"
+)
+
+func TestWithBetween(t *testing.T) {
+	from := time.Unix(1700000000, 0)
+	to := time.Unix(1700086400, 0)
+
+	p := (*GetShopReceiptsParams)(nil).WithCreatedBetween(from, time.Time{}).WithLastModifiedBetween(time.Time{}, to)
+	v, err := query.Values(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := v.Encode(), "max_last_modified=1700086400&min_created=1700000000"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+
+	p.WithCreatedBetween(time.Time{}, time.Time{})
+	if p.MinCreated != nil || p.MaxCreated != nil {
+		t.Errorf("zero bounds not cleared: %v %v", p.MinCreated, p.MaxCreated)
+	}
+}
@@ -0,0 +1,102 @@
+// Package response gives callers access to the raw HTTP response of SDK calls
+// without changing the method signatures of the API clients.
+package response
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// Response is the raw HTTP response of an API call, with the metadata Etsy sends in headers
+type Response struct {
+	StatusCode int
+	Header     http.Header
+	Body       []byte
+
+	// RequestID identifies the request at Etsy; include it in support tickets.
+	RequestID string
+	RateLimit RateLimit
+}
+
+// RateLimit holds the rate-limit counters Etsy returns with every response.
+// Counters missing from the response are -1.
+type RateLimit struct {
+	LimitPerSecond      int
+	RemainingThisSecond int
+	LimitPerDay         int
+	RemainingToday      int
+}
+
+// Header names used by Etsy for rate limits and request IDs
+const (
+	HeaderLimitPerSecond      = "X-Limit-Per-Second"
+	HeaderRemainingThisSecond = "X-Remaining-This-Second"
+	HeaderLimitPerDay         = "X-Limit-Per-Day"
+	HeaderRemainingToday      = "X-Remaining-Today"
+	HeaderEtsyRequestID       = "X-Etsy-Request-Uuid"
+	HeaderRequestID           = "X-Request-Id"
+)
+
+// ParseRateLimit reads the rate-limit counters from h
+func ParseRateLimit(h http.Header) RateLimit {
+	return RateLimit{
+		LimitPerSecond:      headerInt(h, HeaderLimitPerSecond),
+		RemainingThisSecond: headerInt(h, HeaderRemainingThisSecond),
+		LimitPerDay:         headerInt(h, HeaderLimitPerDay),
+		RemainingToday:      headerInt(h, HeaderRemainingToday),
+	}
+}
+
+// RequestID returns the Etsy request ID from h, or "" if there is none
+func RequestID(h http.Header) string {
+	if id := h.Get(HeaderEtsyRequestID); id != "" {
+		return id
+	}
+	return h.Get(HeaderRequestID)
+}
+
+func headerInt(h http.Header, key string) int {
+	n, err := strconv.Atoi(strings.TrimSpace(h.Get(key)))
+	if err != nil {
+		return -1
+	}
+	return n
+}
+
+type contextKey struct{}
+
+// WithRawResponse returns a context that makes the API call made with it record its response into dst,
+// including status, headers, rate-limit counters and request ID. It is filled in for failed calls too.
+//
+//	var raw response.Response
+//	l, err := listingClient.GetListing(response.WithRawResponse(ctx, &raw), id, nil)
+func WithRawResponse(ctx context.Context, dst *Response) context.Context {
+	return context.WithValue(ctx, contextKey{}, dst)
+}
+
+// Capture records rsp into the Response registered on ctx, if any.
+// The body is buffered and rsp.Body replaced so that it can still be read by the caller.
+func Capture(ctx context.Context, rsp *http.Response) error {
+	dst, ok := ctx.Value(contextKey{}).(*Response)
+	if !ok || dst == nil {
+		return nil
+	}
+
+	body, err := io.ReadAll(rsp.Body)
+	rsp.Body.Close()
+	if err != nil {
+		return err
+	}
+	rsp.Body = io.NopCloser(bytes.NewReader(body))
+
+	dst.StatusCode = rsp.StatusCode
+	dst.Header = rsp.Header.Clone()
+	dst.Body = body
+	dst.RequestID = RequestID(rsp.Header)
+	dst.RateLimit = ParseRateLimit(rsp.Header)
+	return nil
+}
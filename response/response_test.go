@@ -0,0 +1,95 @@
+package response
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func get(t *testing.T, handler http.HandlerFunc) *http.Response {
+	t.Helper()
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+	rsp, err := http.Get(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { rsp.Body.Close() })
+	return rsp
+}
+
+func TestCapture(t *testing.T) {
+	rsp := get(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(HeaderLimitPerSecond, "10")
+		w.Header().Set(HeaderRemainingThisSecond, "0")
+		w.Header().Set(HeaderLimitPerDay, "10000")
+		w.Header().Set(HeaderRemainingToday, "9876")
+		w.Header().Set(HeaderEtsyRequestID, "req-1")
+		w.WriteHeader(http.StatusTooManyRequests)
+		io.WriteString(w, `{"error":"slow down"}`)
+	})
+
+	var raw Response
+	if err := Capture(WithRawResponse(context.Background(), &raw), rsp); err != nil {
+		t.Fatal(err)
+	}
+
+	if raw.StatusCode != http.StatusTooManyRequests || string(raw.Body) != `{"error":"slow down"}` || raw.RequestID != "req-1" {
+		t.Errorf("got %+v", raw)
+	}
+	if want := (RateLimit{10, 0, 10000, 9876}); raw.RateLimit != want {
+		t.Errorf("rate limit %+v, want %+v", raw.RateLimit, want)
+	}
+	if raw.Header.Get(HeaderEtsyRequestID) != "req-1" {
+		t.Errorf("headers not copied: %v", raw.Header)
+	}
+
+	body, err := io.ReadAll(rsp.Body)
+	if err != nil || string(body) != `{"error":"slow down"}` {
+		t.Errorf("body after Capture: %q, %v", body, err)
+	}
+}
+
+func TestCaptureWithoutRawResponse(t *testing.T) {
+	rsp := get(t, func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, "ok")
+	})
+	original := rsp.Body
+
+	if err := Capture(context.Background(), rsp); err != nil {
+		t.Fatal(err)
+	}
+	if rsp.Body != original {
+		t.Error("body replaced although no Response was registered")
+	}
+}
+
+func TestParseRateLimitMissingHeaders(t *testing.T) {
+	h := http.Header{}
+	h.Set(HeaderLimitPerDay, " 5000 ")
+	h.Set(HeaderRemainingToday, "unlimited")
+
+	want := RateLimit{LimitPerSecond: -1, RemainingThisSecond: -1, LimitPerDay: 5000, RemainingToday: -1}
+	if got := ParseRateLimit(h); got != want {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+}
+
+func TestRequestID(t *testing.T) {
+	tests := []struct {
+		name   string
+		header http.Header
+		want   string
+	}{
+		{"etsy header", http.Header{HeaderEtsyRequestID: {"etsy"}, HeaderRequestID: {"generic"}}, "etsy"},
+		{"fallback", http.Header{HeaderRequestID: {"generic"}}, "generic"},
+		{"none", http.Header{}, ""},
+	}
+	for _, tt := range tests {
+		if got := RequestID(tt.header); got != tt.want {
+			t.Errorf("%s: got %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
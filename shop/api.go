@@ -0,0 +1,269 @@
+package shop
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	runt "runtime"
+	"strings"
+
+	"github.com/dzt-corp/go-etsy/oauth"
+	"github.com/dzt-corp/go-etsy/response"
+	"github.com/google/go-querystring/query"
+)
+
+// ==========================================
+// Client & Base Infrastructure
+// ==========================================
+
+// RequestBeforeFn is the function signature for the RequestBefore callback function
+type RequestBeforeFn func(ctx context.Context, req *http.Request) error
+
+// ResponseAfterFn is the function signature for the ResponseAfter callback function
+type ResponseAfterFn func(ctx context.Context, rsp *http.Response) error
+
+// HttpRequestDoer performs HTTP requests.
+type HttpRequestDoer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// Client conforms to the OpenAPI3 specification for the Shop service.
+type Client struct {
+	Endpoint      string
+	Client        HttpRequestDoer
+	RequestBefore RequestBeforeFn
+	ResponseAfter ResponseAfterFn
+	UserAgent     string
+}
+
+// ClientOption allows setting custom parameters during construction
+type ClientOption func(*Client) error
+
+// NewClient Creates a new Client with reasonable defaults
+func NewClient(endpoint string, opts ...ClientOption) (*Client, error) {
+	client := Client{
+		Endpoint: endpoint,
+	}
+	for _, o := range opts {
+		if err := o(&client); err != nil {
+			return nil, err
+		}
+	}
+	if !strings.HasSuffix(client.Endpoint, "/") {
+		client.Endpoint += "/"
+	}
+	if client.Client == nil {
+		client.Client = http.DefaultClient
+	}
+	if client.UserAgent == "" {
+		client.UserAgent = fmt.Sprintf("go-etsy-sdk/v1.0 (Language=%s; Platform=%s-%s)", strings.Replace(runt.Version(), "go", "go/", -1), runt.GOOS, runt.GOARCH)
+	}
+	return &client, nil
+}
+
+// WithHTTPClient allows overriding the default Doer
+func WithHTTPClient(doer HttpRequestDoer) ClientOption {
+	return func(c *Client) error {
+		c.Client = doer
+		return nil
+	}
+}
+
+// WithUserAgent sets up the user agent
+func WithUserAgent(userAgent string) ClientOption {
+	return func(c *Client) error {
+		c.UserAgent = userAgent
+		return nil
+	}
+}
+
+// WithRequestBefore allows setting up a callback function before sending the request
+func WithRequestBefore(fn RequestBeforeFn) ClientOption {
+	return func(c *Client) error {
+		c.RequestBefore = fn
+		return nil
+	}
+}
+
+// WithResponseAfter allows setting up a callback function after receiving the response
+func WithResponseAfter(fn ResponseAfterFn) ClientOption {
+	return func(c *Client) error {
+		c.ResponseAfter = fn
+		return nil
+	}
+}
+
+// ==========================================
+// API Operations Interface
+// ==========================================
+
+type ShopAPI interface {
+	// Production partners
+	GetShopProductionPartners(ctx context.Context, shopID int64) (*ProductionPartnersResponse, error)
+	ValidateProductionPartnerIDs(ctx context.Context, shopID int64, partnerIDs []int64) error
+
+	// Holiday preferences
+	GetHolidayPreferences(ctx context.Context, shopID int64) ([]HolidayPreference, error)
+	UpdateHolidayPreferences(ctx context.Context, shopID int64, holidayID HolidayID, body UpdateHolidayPreferencesRequest) (*HolidayPreference, error)
+}
+
+// ==========================================
+// Implementations
+// ==========================================
+
+// GetShopProductionPartners
+// GET /v3/application/shops/{shop_id}/production-partners
+// https://developers.etsy.com/documentation/reference#operation/getShopProductionPartners
+func (c *Client) GetShopProductionPartners(ctx context.Context, shopID int64) (*ProductionPartnersResponse, error) {
+	ctx = oauth.WithOperation(ctx, opGetShopProductionPartners)
+	path := fmt.Sprintf("/v3/application/shops/%d/production-partners", shopID)
+	var dest ProductionPartnersResponse
+	if err := c.do(ctx, "GET", path, nil, nil, &dest); err != nil {
+		return nil, err
+	}
+	return &dest, nil
+}
+
+// ValidateProductionPartnerIDs checks that every ID in partnerIDs is a production partner of the shop,
+// so that CreateDraftListingRequest.ProductionPartnerIDs can be verified before creating a listing.
+func (c *Client) ValidateProductionPartnerIDs(ctx context.Context, shopID int64, partnerIDs []int64) error {
+	if len(partnerIDs) == 0 {
+		return nil
+	}
+
+	partners, err := c.GetShopProductionPartners(ctx, shopID)
+	if err != nil {
+		return err
+	}
+
+	known := make(map[int64]bool, len(partners.Results))
+	for _, p := range partners.Results {
+		known[p.ProductionPartnerID] = true
+	}
+
+	var unknown []string
+	for _, id := range partnerIDs {
+		if !known[id] {
+			unknown = append(unknown, fmt.Sprint(id))
+		}
+	}
+	if len(unknown) > 0 {
+		return fmt.Errorf("unknown production partner IDs for shop %d: %s", shopID, strings.Join(unknown, ", "))
+	}
+	return nil
+}
+
+// GetHolidayPreferences
+// GET /v3/application/shops/{shop_id}/holiday-preferences
+// https://developers.etsy.com/documentation/reference#operation/getHolidayPreferences
+func (c *Client) GetHolidayPreferences(ctx context.Context, shopID int64) ([]HolidayPreference, error) {
+	ctx = oauth.WithOperation(ctx, opGetHolidayPreferences)
+	path := fmt.Sprintf("/v3/application/shops/%d/holiday-preferences", shopID)
+	var dest []HolidayPreference
+	if err := c.do(ctx, "GET", path, nil, nil, &dest); err != nil {
+		return nil, err
+	}
+	return dest, nil
+}
+
+// UpdateHolidayPreferences
+// PUT /v3/application/shops/{shop_id}/holiday-preferences/{holiday_id}
+// https://developers.etsy.com/documentation/reference#operation/updateHolidayPreferences
+func (c *Client) UpdateHolidayPreferences(ctx context.Context, shopID int64, holidayID HolidayID, body UpdateHolidayPreferencesRequest) (*HolidayPreference, error) {
+	ctx = oauth.WithOperation(ctx, opUpdateHolidayPreferences)
+	path := fmt.Sprintf("/v3/application/shops/%d/holiday-preferences/%d", shopID, holidayID)
+	var dest HolidayPreference
+	if err := c.do(ctx, "PUT", path, body, nil, &dest); err != nil {
+		return nil, err
+	}
+	return &dest, nil
+}
+
+// ==========================================
+// Internal Helper Methods
+// ==========================================
+
+// do sends the request and decodes a successful JSON response into dest
+func (c *Client) do(ctx context.Context, method, path string, body interface{}, params interface{}, dest interface{}) error {
+	req, err := c.newRequest(method, path, body, params)
+	if err != nil {
+		return err
+	}
+
+	req = req.WithContext(ctx)
+	if c.RequestBefore != nil {
+		if err := c.RequestBefore(ctx, req); err != nil {
+			return err
+		}
+	}
+
+	rsp, err := c.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer rsp.Body.Close()
+
+	if err := response.Capture(ctx, rsp); err != nil {
+		return err
+	}
+
+	if c.ResponseAfter != nil {
+		if err := c.ResponseAfter(ctx, rsp); err != nil {
+			return err
+		}
+	}
+
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	if err != nil {
+		return err
+	}
+
+	if rsp.StatusCode >= 300 {
+		return &APIError{StatusCode: rsp.StatusCode, Status: rsp.Status, Body: bodyBytes}
+	}
+
+	return json.Unmarshal(bodyBytes, dest)
+}
+
+func (c *Client) newRequest(method, path string, body interface{}, params interface{}) (*http.Request, error) {
+	u, err := url.Parse(c.Endpoint)
+	if err != nil {
+		return nil, err
+	}
+	u, err = u.Parse(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		q, err := query.Values(params)
+		if err != nil {
+			return nil, err
+		}
+		u.RawQuery = q.Encode()
+	}
+
+	bodyReader := strings.NewReader("")
+	if body != nil {
+		formValues, err := query.Values(body)
+		if err != nil {
+			return nil, err
+		}
+		bodyReader = strings.NewReader(formValues.Encode())
+	}
+
+	req, err := http.NewRequest(method, u.String(), bodyReader)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("User-Agent", c.UserAgent)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	}
+
+	return req, nil
+}
@@ -0,0 +1,114 @@
+package shop
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func newTestClient(t *testing.T, handler http.HandlerFunc) *Client {
+	t.Helper()
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+	c, err := NewClient(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+func TestGetHolidayPreferences(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "GET" || r.URL.Path != "/v3/application/shops/7/holiday-preferences" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		fmt.Fprint(w, `[{"shop_id":7,"holiday_id":1,"country_iso":"US","is_working":true,"holiday_name":"New Year's Day","observed_date":"2026-01-01"}]`)
+	})
+
+	prefs, err := c.GetHolidayPreferences(context.Background(), 7)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(prefs) != 1 || prefs[0].HolidayID != 1 || !prefs[0].IsWorking || prefs[0].HolidayName != "New Year's Day" {
+		t.Fatalf("got %+v", prefs)
+	}
+	if string(prefs[0].Extra["observed_date"]) != `"2026-01-01"` {
+		t.Errorf("unknown field not kept: %v", prefs[0].Extra)
+	}
+}
+
+func TestUpdateHolidayPreferences(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "PUT" || r.URL.Path != "/v3/application/shops/7/holiday-preferences/3" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		if err := r.ParseForm(); err != nil {
+			t.Fatal(err)
+		}
+		if got := r.PostForm.Get("is_working"); got != "false" {
+			t.Errorf("is_working = %q, want false", got)
+		}
+		fmt.Fprint(w, `{"shop_id":7,"holiday_id":3,"is_working":false}`)
+	})
+
+	pref, err := c.UpdateHolidayPreferences(context.Background(), 7, 3, UpdateHolidayPreferencesRequest{IsWorking: false})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pref.HolidayID != 3 || pref.IsWorking {
+		t.Errorf("got %+v", pref)
+	}
+}
+
+func TestValidateProductionPartnerIDs(t *testing.T) {
+	calls := 0
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		fmt.Fprint(w, `{"count":2,"results":[{"production_partner_id":10},{"production_partner_id":11}]}`)
+	})
+	ctx := context.Background()
+
+	if err := c.ValidateProductionPartnerIDs(ctx, 7, nil); err != nil || calls != 0 {
+		t.Errorf("no IDs: err %v after %d calls, want nil without calling Etsy", err, calls)
+	}
+	if err := c.ValidateProductionPartnerIDs(ctx, 7, []int64{10, 11}); err != nil {
+		t.Errorf("known IDs: %v", err)
+	}
+	err := c.ValidateProductionPartnerIDs(ctx, 7, []int64{10, 12, 13})
+	if err == nil || !strings.Contains(err.Error(), "12, 13") {
+		t.Errorf("unknown IDs: got %v", err)
+	}
+}
+
+func TestAPIError(t *testing.T) {
+	tests := []struct {
+		status              int
+		notFound, forbidden bool
+	}{
+		{http.StatusNotFound, true, false},
+		{http.StatusForbidden, false, true},
+		{http.StatusInternalServerError, false, false},
+	}
+	for _, tt := range tests {
+		c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(tt.status)
+			fmt.Fprint(w, `{"error":"nope"}`)
+		})
+
+		_, err := c.GetShopProductionPartners(context.Background(), 7)
+		var apiErr *APIError
+		if !errors.As(err, &apiErr) {
+			t.Fatalf("status %d: got %T %v, want *APIError", tt.status, err, err)
+		}
+		if apiErr.StatusCode != tt.status || string(apiErr.Body) != `{"error":"nope"}` {
+			t.Errorf("got %+v", apiErr)
+		}
+		if IsNotFound(err) != tt.notFound || IsForbidden(err) != tt.forbidden {
+			t.Errorf("status %d: IsNotFound %v, IsForbidden %v", tt.status, IsNotFound(err), IsForbidden(err))
+		}
+	}
+}
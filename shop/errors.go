@@ -0,0 +1,33 @@
+package shop
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// APIError is returned when Etsy responds with a non-2xx status code
+type APIError struct {
+	StatusCode int
+	Status     string
+	Body       []byte
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("HTTP error: %s - Body: %s", e.Status, string(e.Body))
+}
+
+// IsNotFound reports whether err is an APIError with status 404
+func IsNotFound(err error) bool {
+	return hasStatus(err, http.StatusNotFound)
+}
+
+// IsForbidden reports whether err is an APIError with status 403, e.g. when the token owner does not own the shop
+func IsForbidden(err error) bool {
+	return hasStatus(err, http.StatusForbidden)
+}
+
+func hasStatus(err error, status int) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == status
+}
@@ -0,0 +1,10 @@
+package shop
+
+import "github.com/dzt-corp/go-etsy/oauth"
+
+// Etsy operations of the shop API and the OAuth scopes they require
+var (
+	opGetShopProductionPartners = oauth.Operation{Name: "getShopProductionPartners", Scopes: oauth.Scopes{oauth.ScopeShopsRead}}
+	opGetHolidayPreferences     = oauth.Operation{Name: "getHolidayPreferences", Scopes: oauth.Scopes{oauth.ScopeShopsRead}}
+	opUpdateHolidayPreferences  = oauth.Operation{Name: "updateHolidayPreferences", Scopes: oauth.Scopes{oauth.ScopeShopsWrite}}
+)
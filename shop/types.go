@@ -0,0 +1,110 @@
+package shop
+
+import (
+	"encoding/json"
+
+	"github.com/dzt-corp/go-etsy/internal/jsonextra"
+	"github.com/dzt-corp/go-etsy/timestamp"
+)
+
+// ==========================================
+// Structs & Models
+// ==========================================
+
+// Every model has an Extra map with the JSON members it does not declare.
+
+// Shop is an Etsy shop
+type Shop struct {
+	ShopID                         int64               `json:"shop_id"`
+	UserID                         int64               `json:"user_id"`
+	ShopName                       string              `json:"shop_name"`
+	CreateDate                     timestamp.Timestamp `json:"create_date"`
+	CreatedTimestamp               timestamp.Timestamp `json:"created_timestamp"`
+	Title                          string              `json:"title"`
+	Announcement                   string              `json:"announcement"`
+	CurrencyCode                   string              `json:"currency_code"`
+	IsVacation                     bool                `json:"is_vacation"`
+	VacationMessage                string              `json:"vacation_message"`
+	SaleMessage                    string              `json:"sale_message"`
+	DigitalSaleMessage             string              `json:"digital_sale_message"`
+	UpdateDate                     timestamp.Timestamp `json:"update_date"`
+	UpdatedTimestamp               timestamp.Timestamp `json:"updated_timestamp"`
+	ListingActiveCount             int                 `json:"listing_active_count"`
+	DigitalListingCount            int                 `json:"digital_listing_count"`
+	LoginName                      string              `json:"login_name"`
+	AcceptsCustomRequests          bool                `json:"accepts_custom_requests"`
+	URL                            string              `json:"url"`
+	IconURLFullxFull               string              `json:"icon_url_fullxfull"`
+	NumFavorers                    int                 `json:"num_favorers"`
+	Languages                      []string            `json:"languages"`
+	ReviewAverage                  float64             `json:"review_average"`
+	ReviewCount                    int                 `json:"review_count"`
+	IsUsingStructuredPolicies      bool                `json:"is_using_structured_policies"`
+	HasOnboardedStructuredPolicies bool                `json:"has_onboarded_structured_policies"`
+
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+func (s *Shop) UnmarshalJSON(data []byte) error {
+	type plain Shop
+	return jsonextra.UnmarshalInto(data, (*plain)(s), &s.Extra)
+}
+
+func (s Shop) MarshalJSON() ([]byte, error) {
+	type plain Shop
+	return jsonextra.MarshalWith(plain(s), s.Extra)
+}
+
+// ProductionPartner is a third party that helps the shop produce its items
+type ProductionPartner struct {
+	ProductionPartnerID int64  `json:"production_partner_id"`
+	PartnerName         string `json:"partner_name"`
+	Location            string `json:"location"`
+
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+func (p *ProductionPartner) UnmarshalJSON(data []byte) error {
+	type plain ProductionPartner
+	return jsonextra.UnmarshalInto(data, (*plain)(p), &p.Extra)
+}
+
+func (p ProductionPartner) MarshalJSON() ([]byte, error) {
+	type plain ProductionPartner
+	return jsonextra.MarshalWith(plain(p), p.Extra)
+}
+
+type ProductionPartnersResponse struct {
+	Count   int                 `json:"count"`
+	Results []ProductionPartner `json:"results"`
+}
+
+// HolidayID identifies a public holiday in Etsy's holiday calendar
+type HolidayID int
+
+// HolidayPreference tells whether the shop processes orders on a given holiday
+type HolidayPreference struct {
+	ShopID      int64     `json:"shop_id"`
+	HolidayID   HolidayID `json:"holiday_id"`
+	CountryISO  string    `json:"country_iso"`
+	IsWorking   bool      `json:"is_working"`
+	HolidayName string    `json:"holiday_name"`
+
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+func (h *HolidayPreference) UnmarshalJSON(data []byte) error {
+	type plain HolidayPreference
+	return jsonextra.UnmarshalInto(data, (*plain)(h), &h.Extra)
+}
+
+func (h HolidayPreference) MarshalJSON() ([]byte, error) {
+	type plain HolidayPreference
+	return jsonextra.MarshalWith(plain(h), h.Extra)
+}
+
+// --- Request Bodies ---
+
+type UpdateHolidayPreferencesRequest struct {
+	IsWorking bool `url:"is_working"`
+}
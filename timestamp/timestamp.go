@@ -0,0 +1,65 @@
+// Package timestamp converts Etsy's Unix-second timestamp fields to and from time.Time.
+package timestamp
+
+import (
+	"bytes"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// Timestamp is a time.Time that decodes from and encodes to Etsy's Unix timestamps (seconds).
+// Etsy's null and 0 both decode to the zero time.
+type Timestamp struct {
+	time.Time
+}
+
+// New wraps t as a Timestamp
+func New(t time.Time) Timestamp {
+	return Timestamp{Time: t}
+}
+
+// FromUnix returns the Timestamp for sec seconds since the Unix epoch; 0 yields the zero Timestamp
+func FromUnix(sec int64) Timestamp {
+	if sec == 0 {
+		return Timestamp{}
+	}
+	return Timestamp{Time: time.Unix(sec, 0).UTC()}
+}
+
+// UnixOrZero returns the Unix seconds of t, or 0 for the zero Timestamp
+func (t Timestamp) UnixOrZero() int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.Unix()
+}
+
+func (t *Timestamp) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		*t = Timestamp{}
+		return nil
+	}
+	sec, err := strconv.ParseFloat(string(bytes.Trim(data, `"`)), 64)
+	if err != nil {
+		return fmt.Errorf("invalid Unix timestamp %s: %w", data, err)
+	}
+	*t = FromUnix(int64(sec))
+	return nil
+}
+
+func (t Timestamp) MarshalJSON() ([]byte, error) {
+	if t.IsZero() {
+		return []byte("null"), nil
+	}
+	return []byte(strconv.FormatInt(t.Unix(), 10)), nil
+}
+
+// EncodeValues lets Timestamp be used in query parameters as Unix seconds
+func (t Timestamp) EncodeValues(key string, v *url.Values) error {
+	if !t.IsZero() {
+		v.Set(key, strconv.FormatInt(t.Unix(), 10))
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+package timestamp
+
+import (
+	"encoding/json"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestUnmarshalJSON(t *testing.T) {
+	want := time.Date(2023, 11, 14, 22, 13, 20, 0, time.UTC) // 1700000000
+	tests := []struct {
+		in   string
+		want time.Time
+	}{
+		{`1700000000`, want},
+		{`"1700000000"`, want},
+		{`1700000000.75`, want},
+		{`null`, time.Time{}},
+		{`0`, time.Time{}},
+		{`"0"`, time.Time{}},
+	}
+	for _, tt := range tests {
+		ts := New(time.Now()) // must be overwritten, including by null
+		if err := json.Unmarshal([]byte(tt.in), &ts); err != nil {
+			t.Errorf("%s: %v", tt.in, err)
+			continue
+		}
+		if !ts.Equal(tt.want) || ts.IsZero() != tt.want.IsZero() {
+			t.Errorf("%s: got %v, want %v", tt.in, ts.Time, tt.want)
+		}
+	}
+
+	for _, in := range []string{`"yesterday"`, `true`, `""`} {
+		var ts Timestamp
+		if err := json.Unmarshal([]byte(in), &ts); err == nil {
+			t.Errorf("%s: expected an error", in)
+		}
+	}
+}
+
+func TestMarshalJSON(t *testing.T) {
+	v := struct {
+		Created Timestamp `json:"created"`
+		Updated Timestamp `json:"updated"`
+	}{Created: FromUnix(1700000000)}
+
+	out, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(out) != `{"created":1700000000,"updated":null}` {
+		t.Errorf("got %s", out)
+	}
+}
+
+func TestUnixHelpers(t *testing.T) {
+	if !FromUnix(0).IsZero() || FromUnix(0).UnixOrZero() != 0 {
+		t.Error("FromUnix(0) is not the zero Timestamp")
+	}
+	if got := FromUnix(1700000000).UnixOrZero(); got != 1700000000 {
+		t.Errorf("UnixOrZero = %d", got)
+	}
+	if loc := FromUnix(1700000000).Location(); loc != time.UTC {
+		t.Errorf("FromUnix location %v, want UTC", loc)
+	}
+}
+
+func TestEncodeValues(t *testing.T) {
+	v := url.Values{}
+	if err := FromUnix(1700000000).EncodeValues("min_created", &v); err != nil {
+		t.Fatal(err)
+	}
+	if err := (Timestamp{}).EncodeValues("max_created", &v); err != nil {
+		t.Fatal(err)
+	}
+	if got := v.Encode(); got != "min_created=1700000000" {
+		t.Errorf("got %q, want the zero bound dropped", got)
+	}
+}